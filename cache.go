@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/pkg/cache"
+	"github.com/faruqfadhil/venue-api/pkg/config"
+)
+
+// newCache returns the cache of the configured driver, nil for none. An
+// unreachable Redis only logs a warning, reads go to the database meanwhile.
+func newCache(cfg config.Cache, appLog *slog.Logger) cache.Cache {
+	switch cfg.Driver {
+	case "memory":
+		return cache.NewMemory(cfg.Size)
+	case "redis":
+		r := cache.NewRedis(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB, cfg.Redis.PoolSize, time.Duration(cfg.Redis.Timeout))
+		if err := r.Ping(context.Background()); err != nil {
+			appLog.Warn("redis cache unreachable", slog.String("addr", cfg.Redis.Addr), slog.String("error", err.Error()))
+		}
+		return r
+	}
+	return nil
+}
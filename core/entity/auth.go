@@ -1,20 +1,34 @@
 package entity
 
+const (
+	RoleCustomer = "customer"
+	RoleOwner    = "owner"
+	RoleAdmin    = "admin"
+)
+
 type User struct {
 	ID       int    `json:"id"`
 	Email    string `json:"email"`
 	FullName string `json:"fullname" gorm:"column:fullname"`
 	Password string `json:"password"`
+	Role     string `json:"-"`
+	// Locale is the preferred locale of the messages, empty to follow the
+	// Accept-Language header.
+	Locale string `json:"locale"`
 }
 
 type Auth struct {
 	Email       string `json:"email"`
 	FullName    string `json:"fullname"`
+	Role        string `json:"role"`
 	AccessToken string `json:"accessToken"`
+	Locale      string `json:"locale,omitempty"`
 }
 
 type CredentialClaim struct {
 	ID       int
 	Email    string
 	FullName string
+	Role     string
+	Locale   string
 }
@@ -0,0 +1,82 @@
+package entity
+
+import "time"
+
+type OwnerOrderQuery struct {
+	VenueIDs  []int
+	PackageID int
+	Status    string
+	StartDate time.Time
+	EndDate   time.Time
+	Page      int
+	Limit     int
+	// IsWithoutPagination returns every matching order, Page and Limit are
+	// ignored.
+	IsWithoutPagination bool
+}
+
+type OwnerOrder struct {
+	ID            int       `json:"id"`
+	InvoiceNumber string    `json:"invoiceNumber"`
+	VenueID       int       `json:"venueId"`
+	VenueName     string    `json:"venueName"`
+	PackageID     int       `json:"packageId"`
+	PackageName   string    `json:"packageName"`
+	CustomerName  string    `json:"customerName"`
+	CustomerEmail string    `json:"customerEmail"`
+	Date          time.Time `json:"date"`
+	Status        string    `json:"status"`
+	PaymentStatus string    `json:"paymentStatus"`
+	TotalPrice    float64   `json:"totalPrice"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type OccupancyDay struct {
+	Date           time.Time         `json:"date"`
+	TotalPackages  int               `json:"totalPackages"`
+	BookedPackages int               `json:"bookedPackages"`
+	Orders         []*OccupancyOrder `json:"orders"`
+}
+
+type OccupancyOrder struct {
+	OrderID     int    `json:"orderId"`
+	PackageID   int    `json:"packageId"`
+	PackageName string `json:"packageName"`
+	Status      string `json:"status"`
+}
+
+// VenueStatsQuery filters statistics by the time the orders were made, so
+// they can be compared with the detail views of the same period.
+type VenueStatsQuery struct {
+	VenueIDs  []int
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// VenueStats sums the bookings of the venues. CancelledBookings is zero while
+// no endpoint cancels an order.
+type VenueStats struct {
+	StartDate         time.Time             `json:"startDate"`
+	EndDate           time.Time             `json:"endDate"`
+	TotalBookings     int                   `json:"totalBookings"`
+	CancelledBookings int                   `json:"cancelledBookings"`
+	Revenue           float64               `json:"revenue"`
+	DetailViews       int                   `json:"detailViews"`
+	ConversionRate    float64               `json:"conversionRate"`
+	BookingsPerMonth  []*MonthlyBookingStat `json:"bookingsPerMonth"`
+	TopPackages       []*PackageBookingStat `json:"topPackages"`
+}
+
+type MonthlyBookingStat struct {
+	Month     string  `json:"month"`
+	Bookings  int     `json:"bookings"`
+	Cancelled int     `json:"cancelled"`
+	Revenue   float64 `json:"revenue"`
+}
+
+type PackageBookingStat struct {
+	PackageID   int     `json:"packageId"`
+	PackageName string  `json:"packageName"`
+	Bookings    int     `json:"bookings"`
+	Revenue     float64 `json:"revenue"`
+}
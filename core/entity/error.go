@@ -0,0 +1,80 @@
+package entity
+
+// Error codes sent to clients in meta.errorCode. They are part of the API
+// contract: add new ones freely but never rename or reuse one.
+const (
+	ErrCodeInvalidCredentials   = "INVALID_CREDENTIALS"
+	ErrCodeInvalidToken         = "INVALID_TOKEN"
+	ErrCodeRoleNotAllowed       = "ROLE_NOT_ALLOWED"
+	ErrCodeEmailTaken           = "EMAIL_TAKEN"
+	ErrCodeVenueNotFound        = "VENUE_NOT_FOUND"
+	ErrCodeCategoryNotFound     = "CATEGORY_NOT_FOUND"
+	ErrCodePackageNotFound      = "PACKAGE_NOT_FOUND"
+	ErrCodeOrderNotFound        = "ORDER_NOT_FOUND"
+	ErrCodeCalendarNotFound     = "CALENDAR_NOT_FOUND"
+	ErrCodeReportNotFound       = "REPORT_NOT_FOUND"
+	ErrCodeDateInPast           = "DATE_IN_PAST"
+	ErrCodeDateUnavailable      = "DATE_UNAVAILABLE"
+	ErrCodeAddonUnavailable     = "ADDON_UNAVAILABLE"
+	ErrCodeAddonQuantity        = "ADDON_QUANTITY_OUT_OF_RANGE"
+	ErrCodePromoNotFound        = "PROMO_NOT_FOUND"
+	ErrCodePromoCodeTaken       = "PROMO_CODE_TAKEN"
+	ErrCodePromoInactive        = "PROMO_INACTIVE"
+	ErrCodePromoNotApplicable   = "PROMO_NOT_APPLICABLE"
+	ErrCodePromoMinSpend        = "PROMO_MIN_SPEND_NOT_MET"
+	ErrCodePromoQuotaExhausted  = "PROMO_QUOTA_EXHAUSTED"
+	ErrCodePromoUserLimit       = "PROMO_USER_LIMIT_REACHED"
+	ErrCodeImportInvalid        = "IMPORT_INVALID"
+	ErrCodePriceRuleNotFound    = "PRICE_RULE_NOT_FOUND"
+	ErrCodePriceRuleInvalid     = "PRICE_RULE_INVALID"
+	ErrCodeValidationFailed     = "VALIDATION_FAILED"
+	ErrCodeInvalidID            = "INVALID_ID"
+	ErrCodeInvalidDateRange     = "INVALID_DATE_RANGE"
+	ErrCodeInvalidGroupBy       = "INVALID_GROUP_BY"
+	ErrCodePromoInvalid         = "PROMO_INVALID"
+	ErrCodeNotReady             = "NOT_READY"
+	ErrCodeLocaleNotSupported   = "LOCALE_NOT_SUPPORTED"
+	ErrCodeFieldNotTranslatable = "FIELD_NOT_TRANSLATABLE"
+	ErrCodeInvalidCursor        = "INVALID_CURSOR"
+	ErrCodeSortNotSupported     = "SORT_NOT_SUPPORTED"
+	ErrCodeUserNotFound         = "USER_NOT_FOUND"
+)
+
+// Message keys of the codes having several messages, see pkg/i18n.
+const (
+	MsgOrderPackageNotFound     = "PACKAGE_NOT_FOUND.order"
+	MsgAddonQuantityBelowMin    = "ADDON_QUANTITY_OUT_OF_RANGE.min"
+	MsgAddonQuantityAboveMax    = "ADDON_QUANTITY_OUT_OF_RANGE.max"
+	MsgPromoNotForPackage       = "PROMO_NOT_APPLICABLE.package"
+	MsgPromoNotForVenue         = "PROMO_NOT_APPLICABLE.venue"
+	MsgPromoNotForCity          = "PROMO_NOT_APPLICABLE.city"
+	MsgPromoCodeRequired        = "PROMO_INVALID.code_required"
+	MsgPromoDiscountType        = "PROMO_INVALID.discount_type"
+	MsgPromoPercentageTooHigh   = "PROMO_INVALID.percentage_too_high"
+	MsgPromoDiscountNotPositive = "PROMO_INVALID.discount_not_positive"
+	MsgPromoNegativeLimit       = "PROMO_INVALID.negative_limit"
+	MsgPromoPeriod              = "PROMO_INVALID.period"
+	MsgImportFormat             = "IMPORT_INVALID.format"
+	MsgImportFileRequired       = "IMPORT_INVALID.file_required"
+	MsgImportFileUnreadable     = "IMPORT_INVALID.file_unreadable"
+	MsgImportCSVHeader          = "IMPORT_INVALID.csv_header"
+	MsgImportCSV                = "IMPORT_INVALID.csv"
+	MsgImportJSON               = "IMPORT_INVALID.json"
+	MsgImportMissingColumn      = "IMPORT_INVALID.missing_column"
+	MsgImportRowRequired        = "IMPORT_INVALID.row_required"
+	MsgImportRowInteger         = "IMPORT_INVALID.row_integer"
+	MsgImportRowNumber          = "IMPORT_INVALID.row_number"
+	MsgImportRowNegative        = "IMPORT_INVALID.row_negative"
+	MsgImportRowTooLong         = "IMPORT_INVALID.row_too_long"
+	MsgImportRowType            = "IMPORT_INVALID.row_type"
+	MsgImportRowParent          = "IMPORT_INVALID.row_parent"
+	MsgImportRowDuplicate       = "IMPORT_INVALID.row_duplicate"
+	MsgImportRowCity            = "IMPORT_INVALID.row_city"
+	MsgImportRowEmail           = "IMPORT_INVALID.row_email"
+	MsgImportRowTimezone        = "IMPORT_INVALID.row_timezone"
+	MsgPriceRuleDaysRequired    = "PRICE_RULE_INVALID.days_required"
+	MsgPriceRuleDateRequired    = "PRICE_RULE_INVALID.date_required"
+	MsgPriceRulePeriod          = "PRICE_RULE_INVALID.period"
+	MsgPriceRuleLeadDays        = "PRICE_RULE_INVALID.lead_days"
+	MsgPriceRuleAdjustment      = "PRICE_RULE_INVALID.adjustment"
+)
@@ -0,0 +1,91 @@
+package entity
+
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+
+	ImportTypeVenue    = "venue"
+	ImportTypeGallery  = "gallery"
+	ImportTypeCategory = "category"
+	ImportTypePackage  = "package"
+)
+
+// VenueImport is the nested representation of an import file. Every record is
+// identified by its ExternalID, importing a record whose ExternalID already
+// exists updates it instead of creating a new one.
+type VenueImport struct {
+	Venues []*ImportVenue `json:"venues"`
+}
+
+type ImportVenue struct {
+	Line         int               `json:"-"`
+	ExternalID   string            `json:"externalId"`
+	Name         string            `json:"name"`
+	CityID       int               `json:"cityId"`
+	Capacity     int               `json:"capacity"`
+	ThumbnailURL string            `json:"thumbnailUrl"`
+	Description  string            `json:"description"`
+	Website      string            `json:"website"`
+	Phone        string            `json:"phone"`
+	Email        string            `json:"email"`
+	Instagram    string            `json:"instagram"`
+	Address      string            `json:"address"`
+	Logo         string            `json:"logo"`
+	Timezone     string            `json:"timezone"`
+	Galleries    []*ImportGallery  `json:"galleries"`
+	Categories   []*ImportCategory `json:"categories"`
+}
+
+type ImportGallery struct {
+	Line       int    `json:"-"`
+	ExternalID string `json:"externalId"`
+	FileURL    string `json:"fileUrl"`
+}
+
+type ImportCategory struct {
+	Line        int              `json:"-"`
+	ExternalID  string           `json:"externalId"`
+	Description string           `json:"description"`
+	Packages    []*ImportPackage `json:"packages"`
+}
+
+type ImportPackage struct {
+	Line         int     `json:"-"`
+	ExternalID   string  `json:"externalId"`
+	Name         string  `json:"name"`
+	ThumbnailURL string  `json:"thumbnailUrl"`
+	Description  string  `json:"description"`
+	Price        float64 `json:"price"`
+	Capacity     int     `json:"capacity"`
+}
+
+type ImportParam struct {
+	Format     string
+	DryRun     bool
+	ImportedBy string
+}
+
+// ImportRowError points at the offending record, by its line for CSV files
+// and by its path in the document for JSON.
+type ImportRowError struct {
+	Line       int    `json:"line,omitempty"`
+	Path       string `json:"path,omitempty"`
+	ExternalID string `json:"externalId,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Message    string `json:"message"`
+}
+
+type ImportCount struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+type ImportResult struct {
+	DryRun     bool              `json:"dryRun"`
+	Committed  bool              `json:"committed"`
+	Venues     ImportCount       `json:"venues"`
+	Galleries  ImportCount       `json:"galleries"`
+	Categories ImportCount       `json:"categories"`
+	Packages   ImportCount       `json:"packages"`
+	Errors     []*ImportRowError `json:"errors"`
+}
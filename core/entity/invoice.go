@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DocumentTypeInvoice = "invoice"
+	DocumentTypeReceipt = "receipt"
+)
+
+// InvoiceNumber builds the invoice number of an order, e.g.
+// INV/20230219/000042. The order id keeps it unique and the date prefix
+// lets finance sort and group invoices without a lookup.
+func InvoiceNumber(orderID int, createdAt time.Time) string {
+	return fmt.Sprintf("INV/%s/%06d", createdAt.Format("20060102"), orderID)
+}
+
+type Invoice struct {
+	Type          string
+	Number        string
+	IssuedAt      time.Time
+	Order         *Order
+	CustomerName  string
+	CustomerEmail string
+	VenueName     string
+	VenueAddress  string
+	VenuePhone    string
+	PackageName   string
+}
+
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+const (
+	PromoDiscountPercentage = "percentage"
+	PromoDiscountFixed      = "fixed"
+)
+
+type Promo struct {
+	ID                int       `json:"id"`
+	Code              string    `json:"code" binding:"required,max=50"`
+	Description       string    `json:"description"`
+	DiscountType      string    `json:"discountType" binding:"required,oneof=percentage fixed"`
+	DiscountValue     float64   `json:"discountValue" binding:"gt=0"`
+	MaxDiscount       float64   `json:"maxDiscount" binding:"gte=0"`
+	MinSpend          float64   `json:"minSpend" binding:"gte=0"`
+	UsageLimit        int       `json:"usageLimit" binding:"gte=0"`
+	UsageLimitPerUser int       `json:"usageLimitPerUser" binding:"gte=0"`
+	UsedCount         int       `json:"usedCount"`
+	StartsAt          time.Time `json:"startsAt"`
+	EndsAt            time.Time `json:"endsAt"`
+	VenueID           int       `json:"venueId" binding:"gte=0"`
+	CityID            int       `json:"cityId" binding:"gte=0"`
+	PackageID         int       `json:"packageId" binding:"gte=0"`
+	IsActive          bool      `json:"isActive"`
+}
+
+type GetPromosParam struct {
+	Code     string
+	IsActive bool
+	Page     int
+	Limit    int
+}
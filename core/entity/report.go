@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+// Reports and their groupings. No endpoint cancels an order yet, so the
+// cancellation figures stay zero until one does.
+const (
+	ReportBookings      = "bookings"
+	ReportRevenue       = "revenue"
+	ReportCancellations = "cancellations"
+	ReportRegistrations = "registrations"
+
+	ReportGroupByCity  = "city"
+	ReportGroupByVenue = "venue"
+	ReportGroupByDate  = "date"
+	ReportGroupByMonth = "month"
+)
+
+// ReportQuery selects the records between StartDate (inclusive) and EndDate
+// (exclusive), bookings by their booked day and registrations by their
+// creation. Limit 0 means every group, which is used for exports.
+type ReportQuery struct {
+	GroupBy   string
+	StartDate time.Time
+	EndDate   time.Time
+	Page      int
+	Limit     int
+}
+
+type ReportColumn struct {
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+type Report struct {
+	Name    string                   `json:"name"`
+	Columns []*ReportColumn          `json:"columns"`
+	Rows    []map[string]interface{} `json:"rows"`
+}
+
+type BookingReportRow struct {
+	GroupKey   string
+	GroupLabel string
+	Bookings   int
+	Confirmed  int
+	Cancelled  int
+	Gross      float64
+	Discount   float64
+	Tax        float64
+	Revenue    float64
+}
+
+type RegistrationReportRow struct {
+	GroupKey      string
+	Registrations int
+}
+
+// ReportWriter receives a report one record at a time, csv.Writer satisfies
+// it as well as the xlsx writer.
+type ReportWriter interface {
+	Write(record []string) error
+}
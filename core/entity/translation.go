@@ -0,0 +1,46 @@
+package entity
+
+// Kinds of content having translatable fields.
+const (
+	ContentVenue    = "venue"
+	ContentCategory = "category"
+	ContentPackage  = "package"
+)
+
+// Translatable fields.
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+)
+
+// TranslatableFields lists the translatable fields of each kind of content.
+// The content columns hold the default locale, translations of the other
+// locales are stored apart and fall back to them.
+var TranslatableFields = map[string][]string{
+	ContentVenue:    {FieldDescription},
+	ContentCategory: {FieldDescription},
+	ContentPackage:  {FieldName, FieldDescription},
+}
+
+type Translation struct {
+	ContentType string
+	ContentID   int
+	Locale      string
+	Field       string
+	// Value is empty to remove the translation.
+	Value string
+}
+
+type GetTranslationQuery struct {
+	ContentType string
+	ContentIDs  []int
+	Locales     []string
+}
+
+// ContentTranslations are the translations of one venue, category or
+// package, by locale then by field.
+type ContentTranslations struct {
+	ContentType  string                       `json:"contentType"`
+	ContentID    int                          `json:"contentId"`
+	Translations map[string]map[string]string `json:"translations"`
+}
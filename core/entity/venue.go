@@ -5,30 +5,34 @@ import (
 )
 
 type Venue struct {
-	ID           int      `json:"id"`
-	Name         string   `json:"name"`
-	MinPrice     float64  `json:"minPrice"`
-	MaxPrice     float64  `json:"maxPrice"`
-	Capacity     int      `json:"capacity"`
-	Star         float64  `json:"star"`
-	ReviewCount  int      `json:"reviewCount"`
-	ThumbnailURL string   `json:"thumbnailUrl"`
-	CityID       int      `json:"cityID"`
-	City         *City    `json:"city"`
-	Description  string   `json:"description"`
-	Website      string   `json:"website"`
-	Phone        string   `json:"phone"`
-	Email        string   `json:"email"`
-	Instagram    string   `json:"instagram"`
-	Address      string   `json:"address"`
-	Logo         string   `json:"logo"`
-	IsFavourite  bool     `json:"isFavourite"`
-	Gallery      []string `json:"gallery"`
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	MinPrice      float64   `json:"minPrice"`
+	MaxPrice      float64   `json:"maxPrice"`
+	Capacity      int       `json:"capacity"`
+	Star          float64   `json:"star"`
+	ReviewCount   int       `json:"reviewCount"`
+	ThumbnailURL  string    `json:"thumbnailUrl"`
+	CityID        int       `json:"cityID"`
+	City          *City     `json:"city"`
+	Description   string    `json:"description"`
+	Website       string    `json:"website"`
+	Phone         string    `json:"phone"`
+	Email         string    `json:"email"`
+	Instagram     string    `json:"instagram"`
+	Address       string    `json:"address"`
+	Logo          string    `json:"logo"`
+	IsFavourite   bool      `json:"isFavourite"`
+	Gallery       []string  `json:"gallery"`
+	Timezone      string    `json:"timezone"`
+	CalendarToken string    `json:"-"`
+	UpdatedAt     time.Time `json:"-"`
 }
 
 type City struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"-"`
 }
 
 type VenueDetail struct {
@@ -41,7 +45,10 @@ type VenueDetail struct {
 	Instagram   string                  `json:"instagram"`
 	Address     string                  `json:"address"`
 	Logo        string                  `json:"logo"`
+	Timezone    string                  `json:"timezone"`
 	Categories  []*VenuePackageCategory `json:"categories"`
+	// UpdatedAt is the last change of the venue, its categories or packages.
+	UpdatedAt time.Time `json:"-"`
 }
 
 type VenuePackageCategory struct {
@@ -49,20 +56,23 @@ type VenuePackageCategory struct {
 	VenueID     int             `json:"venueId"`
 	Description string          `json:"description"`
 	Packages    []*VenuePackage `json:"packages"`
+	UpdatedAt   time.Time       `json:"-"`
 }
 
 type VenuePackage struct {
-	ID           int     `json:"id"`
-	CategoryID   int     `json:"categoryId"`
-	ThumbnailURL string  `json:"thumbnailUrl"`
-	Name         string  `json:"name"`
-	Price        float64 `json:"price"`
-	Capacity     int     `json:"capacity"`
-	Description  string  `json:"description"`
+	ID           int       `json:"id"`
+	CategoryID   int       `json:"categoryId"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	Name         string    `json:"name"`
+	Price        float64   `json:"price"`
+	Capacity     int       `json:"capacity"`
+	Description  string    `json:"description"`
+	UpdatedAt    time.Time `json:"-"`
 }
 
 type GetVenuesParam struct {
 	ID                  int
+	IDs                 []int
 	CityID              int
 	CityIDs             []int
 	IsFavourite         bool
@@ -71,20 +81,93 @@ type GetVenuesParam struct {
 	Limit               int
 	NotInIDs            []int
 	IsWithoutPagination bool
+	// Sort is a VenueSort option, Cursor continues a previous list with the
+	// same Sort and replaces Page.
+	Sort   string
+	Cursor string
+	// SkipCount leaves the total items and pages out.
+	SkipCount bool
+	// Fields are the JSON fields of the venues wanted, all when empty.
+	Fields []string
 }
 
+// Sort options of the venue list, a leading - sorts descending.
+const (
+	VenueSortID       = "id"
+	VenueSortPrice    = "price"
+	VenueSortStar     = "star"
+	VenueSortCapacity = "capacity"
+)
+
 type Pagination struct {
 	Page         int
 	TotalPage    int
 	CurrentItems int
 	TotalItems   int
+	// NextCursor fetches the next items, empty on the last page.
+	NextCursor string
 }
 
 type Order struct {
-	ID        int
-	PackageID int
-	UserID    int
-	Date      time.Time
+	ID            int           `json:"id"`
+	PackageID     int           `json:"packageId"`
+	UserID        int           `json:"userId"`
+	Date          time.Time     `json:"date"`
+	Status        string        `json:"status"`
+	PackagePrice  float64       `json:"packagePrice"`
+	AddonPrice    float64       `json:"addonPrice"`
+	PromoID       int           `json:"promoId"`
+	PromoCode     string        `json:"promoCode"`
+	Discount      float64       `json:"discount"`
+	Tax           float64       `json:"tax"`
+	TotalPrice    float64       `json:"totalPrice"`
+	InvoiceNumber string        `json:"invoiceNumber"`
+	PaymentStatus string        `json:"paymentStatus"`
+	PaidAt        *time.Time    `json:"paidAt"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	Addons        []*OrderAddon `json:"addons" gorm:"-"`
+}
+
+const (
+	OrderStatusConfirmed = "confirmed"
+	OrderStatusCancelled = "cancelled"
+
+	PaymentStatusUnpaid = "unpaid"
+	PaymentStatusPaid   = "paid"
+)
+
+type GetOrderQuery struct {
+	PackageIDs []int
+	Statuses   []string
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+type OrderAddon struct {
+	ID        int     `json:"id"`
+	OrderID   int     `json:"orderId"`
+	AddonID   int     `json:"addonId"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unitPrice"`
+	Quantity  int     `json:"quantity"`
+	Subtotal  float64 `json:"subtotal"`
+}
+
+type PackageAddon struct {
+	ID          int       `json:"id"`
+	PackageID   int       `json:"packageId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Unit        string    `json:"unit"`
+	UnitPrice   float64   `json:"unitPrice"`
+	MinQuantity int       `json:"minQuantity"`
+	MaxQuantity int       `json:"maxQuantity"`
+	UpdatedAt   time.Time `json:"-"`
+}
+
+type GetPackageAddonQuery struct {
+	IDs        []int
+	PackageIDs []int
 }
 
 type GetVenuePackageQuery struct {
@@ -93,8 +176,9 @@ type GetVenuePackageQuery struct {
 }
 
 type GetVenueCategoryByQuery struct {
-	IDs     []int
-	VenueID int
+	IDs      []int
+	VenueID  int
+	VenueIDs []int
 }
 
 type VenueNearby struct {
@@ -105,13 +189,57 @@ type VenueNearby struct {
 }
 
 type PackageDetail struct {
-	ID           int      `json:"id"`
-	ThumbnailURL string   `json:"thumbnailUrl"`
-	Name         string   `json:"name"`
-	Price        float64  `json:"price"`
-	Capacity     int      `json:"capacity"`
-	VenueName    string   `json:"venueName"`
-	VenuePhone   string   `json:"venuePhone"`
-	Gallery      []string `json:"gallery"`
-	Description  string   `json:"description"`
+	ID           int             `json:"id"`
+	ThumbnailURL string          `json:"thumbnailUrl"`
+	Name         string          `json:"name"`
+	Price        float64         `json:"price"`
+	Capacity     int             `json:"capacity"`
+	VenueName    string          `json:"venueName"`
+	VenuePhone   string          `json:"venuePhone"`
+	Gallery      []string        `json:"gallery"`
+	Description  string          `json:"description"`
+	Addons       []*PackageAddon `json:"addons"`
+}
+
+const (
+	PriceRuleTypeDayOfWeek    = "day_of_week"
+	PriceRuleTypeDateRange    = "date_range"
+	PriceRuleTypeSpecificDate = "specific_date"
+	PriceRuleTypeLeadTime     = "lead_time"
+
+	PriceAdjustmentPercentage = "percentage"
+	PriceAdjustmentFixed      = "fixed"
+	PriceAdjustmentOverride   = "override"
+)
+
+// PriceRule adjusts the price of a package on the dates it matches, see
+// isPriceRuleMatch in core/module for the fields each rule type reads.
+type PriceRule struct {
+	ID              int            `json:"id"`
+	PackageID       int            `json:"packageId"`
+	Name            string         `json:"name" binding:"required,max=100"`
+	RuleType        string         `json:"ruleType" binding:"required,oneof=day_of_week date_range specific_date lead_time"`
+	DaysOfWeek      []time.Weekday `json:"daysOfWeek" binding:"dive,min=0,max=6"`
+	StartDate       time.Time      `json:"startDate"`
+	EndDate         time.Time      `json:"endDate"`
+	MinLeadDays     int            `json:"minLeadDays" binding:"gte=0"`
+	MaxLeadDays     int            `json:"maxLeadDays" binding:"gte=0"`
+	AdjustmentType  string         `json:"adjustmentType" binding:"required,oneof=percentage fixed override"`
+	AdjustmentValue float64        `json:"adjustmentValue"`
+	Priority        int            `json:"priority"`
+}
+
+type PackagePrice struct {
+	PackageID   int                `json:"packageId"`
+	Date        time.Time          `json:"date"`
+	BasePrice   float64            `json:"basePrice"`
+	Price       float64            `json:"price"`
+	Adjustments []*PriceAdjustment `json:"adjustments"`
+}
+
+type PriceAdjustment struct {
+	RuleID int     `json:"ruleId"`
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount"`
 }
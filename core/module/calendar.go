@@ -0,0 +1,189 @@
+package module
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/ical"
+)
+
+const (
+	calendarProdID    = "-//Venue API//Booking Calendar//ID"
+	calendarUIDDomain = "venue-api"
+)
+
+func (u *usecase) GetOrderCalendar(ctx context.Context, ID int, user *entity.CredentialClaim) (*entity.File, error) {
+	order, err := u.getOrderOfUser(ctx, ID, user)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := u.repo.GetPackageByID(ctx, order.PackageID)
+	if err != nil {
+		return nil, err
+	}
+	venue, err := u.getVenueByCategoryID(ctx, pkg.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := orderEvent(order)
+	ev.Summary = fmt.Sprintf("%s - %s", venue.Name, pkg.Name)
+	ev.Description = fmt.Sprintf("Invoice %s", order.InvoiceNumber)
+	ev.Location = venue.Address
+	return calendarFile(fmt.Sprintf("order-%d.ics", order.ID), &ical.Calendar{
+		ProdID: calendarProdID,
+		Events: []*ical.Event{ev},
+	})
+}
+
+func (u *usecase) GetVenueCalendar(ctx context.Context, venueID int, token string) (*entity.File, error) {
+	venues, _, err := u.repo.GetVenues(ctx, entity.GetVenuesParam{
+		ID:                  venueID,
+		IsWithoutPagination: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Unknown venues and wrong tokens look the same to not leak which venue
+	// ids exist.
+	if len(venues) < 1 || venues[0].CalendarToken == "" || subtle.ConstantTimeCompare([]byte(venues[0].CalendarToken), []byte(token)) != 1 {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeCalendarNotFound, fmt.Errorf("invalid calendar token for venue %d", venueID))
+	}
+	venue := venues[0]
+
+	categories, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{
+		VenueID: venue.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs := []int{}
+	for _, ctg := range categories {
+		categoryIDs = append(categoryIDs, ctg.ID)
+	}
+
+	events := []*ical.Event{}
+	if len(categoryIDs) > 0 {
+		packages, err := u.repo.GetVenuePackageByQuery(ctx, &entity.GetVenuePackageQuery{
+			CategoryIDs: categoryIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		packageIDs := []int{}
+		packageMappedByID := map[int]*entity.VenuePackage{}
+		for _, pkg := range packages {
+			packageIDs = append(packageIDs, pkg.ID)
+			packageMappedByID[pkg.ID] = pkg
+		}
+
+		if len(packageIDs) > 0 {
+			orders, err := u.repo.GetOrdersByQuery(ctx, &entity.GetOrderQuery{
+				PackageIDs: packageIDs,
+				Statuses:   []string{entity.OrderStatusConfirmed},
+			})
+			if err != nil {
+				return nil, err
+			}
+			userMappedByID, err := u.getUsersMappedByID(ctx, orders)
+			if err != nil {
+				return nil, err
+			}
+			for _, order := range orders {
+				ev := orderEvent(order)
+				ev.Summary = packageMappedByID[order.PackageID].Name
+				if user, ok := userMappedByID[order.UserID]; ok {
+					ev.Summary = fmt.Sprintf("%s - %s", packageMappedByID[order.PackageID].Name, user.FullName)
+					ev.Description = fmt.Sprintf("Invoice %s\nPemesan: %s (%s)", order.InvoiceNumber, user.FullName, user.Email)
+				}
+				ev.Location = venue.Address
+				events = append(events, ev)
+			}
+		}
+	}
+
+	return calendarFile(fmt.Sprintf("venue-%d.ics", venue.ID), &ical.Calendar{
+		ProdID:   calendarProdID,
+		Name:     venue.Name,
+		Timezone: civil.LoadLocation(venue.Timezone).String(),
+		Events:   events,
+	})
+}
+
+func (u *usecase) RotateVenueCalendarToken(ctx context.Context, venueID int) (string, error) {
+	venues, _, err := u.repo.GetVenues(ctx, entity.GetVenuesParam{
+		ID:                  venueID,
+		IsWithoutPagination: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(venues) < 1 {
+		return "", errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue not found"))
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", errutil.New(errutil.ErrInternal, fmt.Errorf("[RotateVenueCalendarToken] err: %v", err))
+	}
+	token := hex.EncodeToString(b)
+	if err := u.repo.UpdateVenueCalendarToken(ctx, venueID, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (u *usecase) getUsersMappedByID(ctx context.Context, orders []*entity.Order) (map[int]*entity.User, error) {
+	out := map[int]*entity.User{}
+	userIDs := []int{}
+	for _, order := range orders {
+		userIDs = append(userIDs, order.UserID)
+	}
+	if len(userIDs) < 1 {
+		return out, nil
+	}
+	users, err := u.repo.FindUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		out[user.ID] = user
+	}
+	return out, nil
+}
+
+// orderEvent maps the booking to an all-day event, the order date is a
+// calendar date so it is published without a time of day.
+func orderEvent(order *entity.Order) *ical.Event {
+	start := civil.Date(order.Date)
+	status := "CONFIRMED"
+	if order.Status == entity.OrderStatusCancelled {
+		status = "CANCELLED"
+	}
+	return &ical.Event{
+		UID:     ical.UID("order", order.ID, calendarUIDDomain),
+		Start:   start,
+		End:     start.AddDate(0, 0, 1),
+		AllDay:  true,
+		Status:  status,
+		Created: order.CreatedAt,
+	}
+}
+
+func calendarFile(name string, cal *ical.Calendar) (*entity.File, error) {
+	content, err := cal.Bytes()
+	if err != nil {
+		return nil, errutil.New(errutil.ErrInternal, fmt.Errorf("[calendarFile] err: %v", err))
+	}
+	return &entity.File{
+		Name:        name,
+		ContentType: "text/calendar; charset=utf-8",
+		Content:     content,
+	}, nil
+}
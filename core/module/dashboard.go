@@ -0,0 +1,226 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+)
+
+const ownerTopPackagesLimit = 5
+
+func (u *usecase) GetOwnerVenues(ctx context.Context, user *entity.CredentialClaim) ([]*entity.Venue, error) {
+	venueIDs, err := u.getOwnedVenueIDs(ctx, user, 0)
+	if err != nil {
+		return nil, err
+	}
+	if venueIDs != nil && len(venueIDs) < 1 {
+		return []*entity.Venue{}, nil
+	}
+	venues, _, err := u.repo.GetVenues(ctx, entity.GetVenuesParam{
+		IDs:                 venueIDs,
+		IsWithoutPagination: true,
+	})
+	return venues, err
+}
+
+func (u *usecase) GetOwnerOrders(ctx context.Context, user *entity.CredentialClaim, venueID int, param *entity.OwnerOrderQuery) ([]*entity.OwnerOrder, *entity.Pagination, error) {
+	venueIDs, err := u.getOwnedVenueIDs(ctx, user, venueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if venueIDs != nil && len(venueIDs) < 1 {
+		return []*entity.OwnerOrder{}, &entity.Pagination{Page: 1}, nil
+	}
+	param.VenueIDs = venueIDs
+	return u.repo.GetOwnerOrders(ctx, param)
+}
+
+// GetVenueOccupancy lists, for every day of the month, which packages of the
+// venue are booked.
+func (u *usecase) GetVenueOccupancy(ctx context.Context, user *entity.CredentialClaim, venueID int, month time.Time) ([]*entity.OccupancyDay, error) {
+	venueIDs, err := u.getOwnedVenueIDs(ctx, user, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if venueIDs != nil && len(venueIDs) < 1 {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue %d not owned by user %d", venueID, user.ID))
+	}
+
+	categories, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{
+		VenueID: venueID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	totalPackages := 0
+	if len(categories) > 0 {
+		categoryIDs := []int{}
+		for _, ctg := range categories {
+			categoryIDs = append(categoryIDs, ctg.ID)
+		}
+		packages, err := u.repo.GetVenuePackageByQuery(ctx, &entity.GetVenuePackageQuery{
+			CategoryIDs: categoryIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		totalPackages = len(packages)
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+	orders, _, err := u.repo.GetOwnerOrders(ctx, &entity.OwnerOrderQuery{
+		VenueIDs:  []int{venueID},
+		Status:    entity.OrderStatusConfirmed,
+		StartDate: start,
+		EndDate:   end,
+		// Every booking of the month is needed, a page would drop days.
+		IsWithoutPagination: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ordersMappedByDate := map[time.Time][]*entity.OccupancyOrder{}
+	for _, o := range orders {
+		date := civil.Date(o.Date)
+		ordersMappedByDate[date] = append(ordersMappedByDate[date], &entity.OccupancyOrder{
+			OrderID:     o.ID,
+			PackageID:   o.PackageID,
+			PackageName: o.PackageName,
+			Status:      o.Status,
+		})
+	}
+
+	out := []*entity.OccupancyDay{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		dayOrders := ordersMappedByDate[d]
+		if dayOrders == nil {
+			dayOrders = []*entity.OccupancyOrder{}
+		}
+		out = append(out, &entity.OccupancyDay{
+			Date:           d,
+			TotalPackages:  totalPackages,
+			BookedPackages: len(dayOrders),
+			Orders:         dayOrders,
+		})
+	}
+	return out, nil
+}
+
+func (u *usecase) GetVenueStats(ctx context.Context, user *entity.CredentialClaim, venueID int, startDate, endDate time.Time) (*entity.VenueStats, error) {
+	if endDate.IsZero() {
+		endDate = civil.Today(civil.LoadLocation(""))
+	}
+	if startDate.IsZero() {
+		startDate = time.Date(endDate.Year(), endDate.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -11, 0)
+	}
+	if endDate.Before(startDate) {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidDateRange, fmt.Errorf("end date before start date"))
+	}
+	out := &entity.VenueStats{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		BookingsPerMonth: []*entity.MonthlyBookingStat{},
+		TopPackages:      []*entity.PackageBookingStat{},
+	}
+
+	venueIDs, err := u.getOwnedVenueIDs(ctx, user, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if venueIDs != nil && len(venueIDs) < 1 {
+		return out, nil
+	}
+	// The end date is inclusive for the caller.
+	param := &entity.VenueStatsQuery{
+		VenueIDs:  venueIDs,
+		StartDate: startDate,
+		EndDate:   endDate.AddDate(0, 0, 1),
+	}
+
+	monthly, err := u.repo.GetMonthlyBookingStats(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range monthly {
+		out.TotalBookings += m.Bookings
+		out.CancelledBookings += m.Cancelled
+		out.Revenue += m.Revenue
+	}
+	out.BookingsPerMonth = monthly
+
+	top, err := u.repo.GetTopPackageStats(ctx, param, ownerTopPackagesLimit)
+	if err != nil {
+		return nil, err
+	}
+	out.TopPackages = top
+
+	views, err := u.repo.CountVenueViews(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	out.DetailViews = views
+	if views > 0 {
+		out.ConversionRate = math.Round(float64(out.TotalBookings)/float64(views)*10000) / 10000
+	}
+	return out, nil
+}
+
+// AssignVenueOwner lets the user manage the venue from the owner endpoints. A
+// customer becomes an owner, the new role is in the tokens issued from then
+// on.
+func (u *usecase) AssignVenueOwner(ctx context.Context, venueID, userID int) error {
+	venues, _, err := u.repo.GetVenues(ctx, entity.GetVenuesParam{
+		ID:                  venueID,
+		IsWithoutPagination: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(venues) < 1 {
+		return errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue not found"))
+	}
+	if _, err := u.repo.FindUserByID(ctx, userID); err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeUserNotFound, err)
+		}
+		return err
+	}
+	return u.repo.AssignVenueOwner(ctx, venueID, userID)
+}
+
+func (u *usecase) RemoveVenueOwner(ctx context.Context, venueID, userID int) error {
+	return u.repo.RemoveVenueOwner(ctx, venueID, userID)
+}
+
+// getOwnedVenueIDs returns the venues the user may see, optionally narrowed
+// to venueID. A nil result means every venue (admins), an empty one means
+// none.
+func (u *usecase) getOwnedVenueIDs(ctx context.Context, user *entity.CredentialClaim, venueID int) ([]int, error) {
+	if user.Role == entity.RoleAdmin {
+		if venueID > 0 {
+			return []int{venueID}, nil
+		}
+		return nil, nil
+	}
+
+	owned, err := u.repo.GetVenueIDsByOwnerID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if venueID < 1 {
+		return owned, nil
+	}
+	for _, id := range owned {
+		if id == venueID {
+			return []int{venueID}, nil
+		}
+	}
+	return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue %d not owned by user %d", venueID, user.ID))
+}
@@ -0,0 +1,331 @@
+package module
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+)
+
+const (
+	maxExternalIDLength = 64
+	maxPhoneLength      = 13
+)
+
+// ImportVenues reads venues with their galleries, categories and packages from
+// r and upserts them by external id in a single transaction. Nothing is
+// written when any record is invalid, the result then only lists the errors.
+func (u *usecase) ImportVenues(ctx context.Context, r io.Reader, param *entity.ImportParam) (*entity.ImportResult, error) {
+	// Row errors are returned as data, so they are rendered here.
+	locale := i18n.FromContext(ctx)
+	var (
+		data    *entity.VenueImport
+		rowErrs []*entity.ImportRowError
+		err     error
+	)
+	switch param.Format {
+	case entity.ImportFormatCSV:
+		data, rowErrs, err = decodeVenueImportCSV(locale, r)
+	case entity.ImportFormatJSON:
+		data, err = decodeVenueImportJSON(r)
+	default:
+		return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportFormat, fmt.Errorf("unsupported import format %q", param.Format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cities, err := u.repo.GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cityIDs := map[int]bool{}
+	for _, city := range cities {
+		cityIDs[city.ID] = true
+	}
+	rowErrs = append(rowErrs, validateVenueImport(locale, data, cityIDs)...)
+
+	if len(rowErrs) > 0 {
+		return &entity.ImportResult{
+			DryRun: param.DryRun,
+			Errors: rowErrs,
+		}, nil
+	}
+
+	out, err := u.repo.ImportVenues(ctx, data, param)
+	if err != nil {
+		return nil, err
+	}
+	out.DryRun = param.DryRun
+	out.Committed = !param.DryRun
+	out.Errors = []*entity.ImportRowError{}
+	return out, nil
+}
+
+func decodeVenueImportJSON(r io.Reader) (*entity.VenueImport, error) {
+	var data entity.VenueImport
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportJSON, fmt.Errorf("[decodeVenueImportJSON] err: %v", err), err.Error())
+	}
+	return &data, nil
+}
+
+// decodeVenueImportCSV reads a flat CSV where every line is one record, the
+// type column tells which kind and parent_external_id links galleries and
+// categories to their venue and packages to their category. Columns are
+// matched by header name so they may come in any order.
+func decodeVenueImportCSV(locale string, r io.Reader) (*entity.VenueImport, []*entity.ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportCSVHeader, fmt.Errorf("[decodeVenueImportCSV] err: %v", err))
+	}
+	columns := map[string]int{}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		columns[h] = i
+	}
+	for _, required := range []string{"type", "external_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportMissingColumn, fmt.Errorf("missing column %s", required), required)
+		}
+	}
+
+	data := &entity.VenueImport{Venues: []*entity.ImportVenue{}}
+	rowErrs := []*entity.ImportRowError{}
+	venues := map[string]*entity.ImportVenue{}
+	categories := map[string]*entity.ImportCategory{}
+	type child struct {
+		line       int
+		externalID string
+		parentID   string
+		link       func() bool
+	}
+	children := []*child{}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportCSV, fmt.Errorf("[decodeVenueImportCSV] err: %v", err), err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+		get := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		getInt := func(name string) int {
+			v := get(name)
+			if v == "" {
+				return 0
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				rowErrs = append(rowErrs, &entity.ImportRowError{Line: line, ExternalID: get("external_id"), Field: name, Message: i18n.T(locale, entity.MsgImportRowInteger)})
+			}
+			return n
+		}
+		getFloat := func(name string) float64 {
+			v := get(name)
+			if v == "" {
+				return 0
+			}
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				rowErrs = append(rowErrs, &entity.ImportRowError{Line: line, ExternalID: get("external_id"), Field: name, Message: i18n.T(locale, entity.MsgImportRowNumber)})
+			}
+			return n
+		}
+
+		externalID := get("external_id")
+		parentID := get("parent_external_id")
+		switch strings.ToLower(get("type")) {
+		case entity.ImportTypeVenue:
+			v := &entity.ImportVenue{
+				Line:         line,
+				ExternalID:   externalID,
+				Name:         get("name"),
+				CityID:       getInt("city_id"),
+				Capacity:     getInt("capacity"),
+				ThumbnailURL: get("thumbnail_url"),
+				Description:  get("description"),
+				Website:      get("website"),
+				Phone:        get("phone"),
+				Email:        get("email"),
+				Instagram:    get("instagram"),
+				Address:      get("address"),
+				Logo:         get("logo"),
+				Timezone:     get("timezone"),
+			}
+			data.Venues = append(data.Venues, v)
+			if _, ok := venues[externalID]; !ok {
+				venues[externalID] = v
+			}
+		case entity.ImportTypeGallery:
+			g := &entity.ImportGallery{Line: line, ExternalID: externalID, FileURL: get("file_url")}
+			children = append(children, &child{line: line, externalID: externalID, parentID: parentID, link: func() bool {
+				v, ok := venues[parentID]
+				if ok {
+					v.Galleries = append(v.Galleries, g)
+				}
+				return ok
+			}})
+		case entity.ImportTypeCategory:
+			c := &entity.ImportCategory{Line: line, ExternalID: externalID, Description: get("description")}
+			if _, ok := categories[externalID]; !ok {
+				categories[externalID] = c
+			}
+			children = append(children, &child{line: line, externalID: externalID, parentID: parentID, link: func() bool {
+				v, ok := venues[parentID]
+				if ok {
+					v.Categories = append(v.Categories, c)
+				}
+				return ok
+			}})
+		case entity.ImportTypePackage:
+			p := &entity.ImportPackage{
+				Line:         line,
+				ExternalID:   externalID,
+				Name:         get("name"),
+				ThumbnailURL: get("thumbnail_url"),
+				Description:  get("description"),
+				Price:        getFloat("price"),
+				Capacity:     getInt("capacity"),
+			}
+			children = append(children, &child{line: line, externalID: externalID, parentID: parentID, link: func() bool {
+				c, ok := categories[parentID]
+				if ok {
+					c.Packages = append(c.Packages, p)
+				}
+				return ok
+			}})
+		default:
+			rowErrs = append(rowErrs, &entity.ImportRowError{Line: line, ExternalID: externalID, Field: "type", Message: i18n.T(locale, entity.MsgImportRowType)})
+		}
+	}
+
+	// Categories are linked before packages are, children keep file order.
+	for _, ch := range children {
+		if !ch.link() {
+			rowErrs = append(rowErrs, &entity.ImportRowError{Line: ch.line, ExternalID: ch.externalID, Field: "parent_external_id", Message: i18n.T(locale, entity.MsgImportRowParent, ch.parentID)})
+		}
+	}
+	return data, rowErrs, nil
+}
+
+func validateVenueImport(locale string, data *entity.VenueImport, cityIDs map[int]bool) []*entity.ImportRowError {
+	out := []*entity.ImportRowError{}
+	seen := map[string]map[string]bool{}
+	check := func(line int, path, kind, externalID string, fieldErrs map[string]string) {
+		if externalID == "" {
+			fieldErrs["externalId"] = i18n.T(locale, entity.MsgImportRowRequired)
+		} else if len(externalID) > maxExternalIDLength {
+			fieldErrs["externalId"] = i18n.T(locale, entity.MsgImportRowTooLong, maxExternalIDLength)
+		} else {
+			if seen[kind] == nil {
+				seen[kind] = map[string]bool{}
+			}
+			if seen[kind][externalID] {
+				fieldErrs["externalId"] = i18n.T(locale, entity.MsgImportRowDuplicate, kind)
+			}
+			seen[kind][externalID] = true
+		}
+		for _, field := range sortedKeys(fieldErrs) {
+			rowErr := &entity.ImportRowError{Line: line, ExternalID: externalID, Field: field, Message: fieldErrs[field]}
+			if line == 0 {
+				rowErr.Path = path
+			}
+			out = append(out, rowErr)
+		}
+	}
+
+	for i, v := range data.Venues {
+		path := fmt.Sprintf("venues[%d]", i)
+		fieldErrs := map[string]string{}
+		if strings.TrimSpace(v.Name) == "" {
+			fieldErrs["name"] = i18n.T(locale, entity.MsgImportRowRequired)
+		}
+		if !cityIDs[v.CityID] {
+			fieldErrs["cityId"] = i18n.T(locale, entity.MsgImportRowCity, v.CityID)
+		}
+		if v.Capacity < 0 {
+			fieldErrs["capacity"] = i18n.T(locale, entity.MsgImportRowNegative)
+		}
+		if len(v.Phone) > maxPhoneLength {
+			fieldErrs["phone"] = i18n.T(locale, entity.MsgImportRowTooLong, maxPhoneLength)
+		}
+		if v.Email != "" {
+			if _, err := mail.ParseAddress(v.Email); err != nil {
+				fieldErrs["email"] = i18n.T(locale, entity.MsgImportRowEmail)
+			}
+		}
+		if v.Timezone == "" {
+			v.Timezone = civil.DefaultTimezone
+		} else if !civil.IsValidTimezone(v.Timezone) {
+			fieldErrs["timezone"] = i18n.T(locale, entity.MsgImportRowTimezone)
+		}
+		check(v.Line, path, entity.ImportTypeVenue, v.ExternalID, fieldErrs)
+
+		for j, g := range v.Galleries {
+			fieldErrs := map[string]string{}
+			if strings.TrimSpace(g.FileURL) == "" {
+				fieldErrs["fileUrl"] = i18n.T(locale, entity.MsgImportRowRequired)
+			}
+			check(g.Line, fmt.Sprintf("%s.galleries[%d]", path, j), entity.ImportTypeGallery, g.ExternalID, fieldErrs)
+		}
+
+		for j, c := range v.Categories {
+			categoryPath := fmt.Sprintf("%s.categories[%d]", path, j)
+			fieldErrs := map[string]string{}
+			if strings.TrimSpace(c.Description) == "" {
+				fieldErrs["description"] = i18n.T(locale, entity.MsgImportRowRequired)
+			}
+			check(c.Line, categoryPath, entity.ImportTypeCategory, c.ExternalID, fieldErrs)
+
+			for k, p := range c.Packages {
+				fieldErrs := map[string]string{}
+				if strings.TrimSpace(p.Name) == "" {
+					fieldErrs["name"] = i18n.T(locale, entity.MsgImportRowRequired)
+				}
+				if p.Price < 0 {
+					fieldErrs["price"] = i18n.T(locale, entity.MsgImportRowNegative)
+				}
+				if p.Capacity < 0 {
+					fieldErrs["capacity"] = i18n.T(locale, entity.MsgImportRowNegative)
+				}
+				check(p.Line, fmt.Sprintf("%s.packages[%d]", categoryPath, k), entity.ImportTypePackage, p.ExternalID, fieldErrs)
+			}
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,232 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/pdf"
+)
+
+func (u *usecase) GetOrderInvoice(ctx context.Context, ID int, user *entity.CredentialClaim) (*entity.File, error) {
+	invoice, err := u.getInvoice(ctx, ID, user)
+	if err != nil {
+		return nil, err
+	}
+	content, err := renderInvoice(invoice)
+	if err != nil {
+		return nil, errutil.New(errutil.ErrInternal, fmt.Errorf("[GetOrderInvoice] err: %v", err))
+	}
+	return &entity.File{
+		Name:        strings.ReplaceAll(invoice.Number, "/", "-") + ".pdf",
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// MarkOrderPaid records that the order was paid, its invoice is then issued
+// as a receipt. Marking a paid order again changes nothing.
+func (u *usecase) MarkOrderPaid(ctx context.Context, ID int) (*entity.Order, error) {
+	order, err := u.repo.GetOrderByID(ctx, ID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeOrderNotFound, err)
+		}
+		return nil, err
+	}
+	if order.PaymentStatus == entity.PaymentStatusPaid {
+		return order, nil
+	}
+	paidAt := time.Now().UTC()
+	if err := u.repo.MarkOrderPaid(ctx, ID, paidAt); err != nil {
+		return nil, err
+	}
+	order.PaymentStatus = entity.PaymentStatusPaid
+	order.PaidAt = &paidAt
+	return order, nil
+}
+
+// getOrderOfUser returns the order only when it belongs to the user, admins
+// can access every order.
+func (u *usecase) getOrderOfUser(ctx context.Context, ID int, user *entity.CredentialClaim) (*entity.Order, error) {
+	order, err := u.repo.GetOrderByID(ctx, ID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeOrderNotFound, err)
+		}
+		return nil, err
+	}
+	if user.Role != entity.RoleAdmin && order.UserID != user.ID {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeOrderNotFound, fmt.Errorf("order %d doesn't belong to user %d", ID, user.ID))
+	}
+	return order, nil
+}
+
+func (u *usecase) getInvoice(ctx context.Context, ID int, user *entity.CredentialClaim) (*entity.Invoice, error) {
+	order, err := u.getOrderOfUser(ctx, ID, user)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := u.repo.GetPackageByID(ctx, order.PackageID)
+	if err != nil {
+		return nil, err
+	}
+	venue, err := u.getVenueByCategoryID(ctx, pkg.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := u.repo.FindUserByID(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &entity.Invoice{
+		Type:          entity.DocumentTypeInvoice,
+		Number:        order.InvoiceNumber,
+		IssuedAt:      order.CreatedAt,
+		Order:         order,
+		CustomerName:  customer.FullName,
+		CustomerEmail: customer.Email,
+		VenueName:     venue.Name,
+		VenueAddress:  venue.Address,
+		VenuePhone:    venue.Phone,
+		PackageName:   pkg.Name,
+	}
+	if out.Number == "" {
+		out.Number = entity.InvoiceNumber(order.ID, order.CreatedAt)
+	}
+	if order.PaymentStatus == entity.PaymentStatusPaid {
+		out.Type = entity.DocumentTypeReceipt
+		if order.PaidAt != nil {
+			out.IssuedAt = *order.PaidAt
+		}
+	}
+	out.IssuedAt = out.IssuedAt.In(civil.LoadLocation(venue.Timezone))
+	return out, nil
+}
+
+func renderInvoice(inv *entity.Invoice) ([]byte, error) {
+	const (
+		left  = 50.0
+		right = pdf.PageWidth - 50
+	)
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	title, status := "INVOICE", "BELUM DIBAYAR"
+	if inv.Type == entity.DocumentTypeReceipt {
+		title, status = "KWITANSI", "LUNAS"
+	}
+	page.Text(left, 70, 24, true, title)
+	page.TextRight(right, 62, 10, false, "No. "+inv.Number)
+	page.TextRight(right, 76, 10, false, "Tanggal "+inv.IssuedAt.Format("02 January 2006"))
+	page.TextRight(right, 90, 10, true, "Status: "+status)
+	page.Line(left, 105, right, 105, 1)
+
+	y := 130.0
+	page.Text(left, y, 10, true, "Ditagihkan kepada")
+	page.Text(300, y, 10, true, "Venue")
+	y += 16
+	page.Text(left, y, 10, false, inv.CustomerName)
+	page.Text(300, y, 10, false, inv.VenueName)
+	y += 14
+	page.Text(left, y, 10, false, inv.CustomerEmail)
+	page.Text(300, y, 10, false, inv.VenuePhone)
+	for _, line := range wrapText(inv.VenueAddress, 45) {
+		y += 14
+		page.Text(300, y, 10, false, line)
+	}
+
+	y += 36
+	page.Text(left, y, 10, true, "Deskripsi")
+	page.TextRight(340, y, 10, true, "Jumlah")
+	page.TextRight(440, y, 10, true, "Harga Satuan")
+	page.TextRight(right, y, 10, true, "Subtotal")
+	y += 8
+	page.Line(left, y, right, y, 0.5)
+
+	y += 18
+	page.Text(left, y, 10, false, fmt.Sprintf("%s (%s)", inv.PackageName, inv.Order.Date.Format("02 Jan 2006")))
+	page.TextRight(340, y, 10, false, "1")
+	page.TextRight(440, y, 10, false, formatRupiah(inv.Order.PackagePrice))
+	page.TextRight(right, y, 10, false, formatRupiah(inv.Order.PackagePrice))
+	for _, ad := range inv.Order.Addons {
+		y += 16
+		page.Text(left, y, 10, false, ad.Name)
+		page.TextRight(340, y, 10, false, fmt.Sprintf("%d", ad.Quantity))
+		page.TextRight(440, y, 10, false, formatRupiah(ad.UnitPrice))
+		page.TextRight(right, y, 10, false, formatRupiah(ad.Subtotal))
+	}
+	y += 10
+	page.Line(left, y, right, y, 0.5)
+
+	summary := [][2]string{
+		{"Subtotal", formatRupiah(inv.Order.PackagePrice + inv.Order.AddonPrice)},
+	}
+	if inv.Order.Discount > 0 {
+		summary = append(summary, [2]string{fmt.Sprintf("Diskon (%s)", inv.Order.PromoCode), "-" + formatRupiah(inv.Order.Discount)})
+	}
+	if inv.Order.Tax > 0 {
+		summary = append(summary, [2]string{"PPN", formatRupiah(inv.Order.Tax)})
+	}
+	for _, row := range summary {
+		y += 18
+		page.TextRight(440, y, 10, false, row[0])
+		page.TextRight(right, y, 10, false, row[1])
+	}
+	y += 22
+	page.TextRight(440, y, 12, true, "Total")
+	page.TextRight(right, y, 12, true, formatRupiah(inv.Order.TotalPrice))
+
+	y += 50
+	if inv.Type == entity.DocumentTypeReceipt {
+		page.Text(left, y, 10, false, fmt.Sprintf("Pembayaran telah diterima pada %s.", inv.IssuedAt.Format("02 January 2006 15:04")))
+	} else {
+		page.Text(left, y, 10, false, "Mohon lakukan pembayaran sebelum tanggal acara.")
+	}
+	page.Text(left, pdf.PageHeight-50, 8, false, fmt.Sprintf("Dokumen ini dibuat secara otomatis pada %s dan sah tanpa tanda tangan.", time.Now().In(inv.IssuedAt.Location()).Format("02 January 2006")))
+
+	return doc.Bytes()
+}
+
+// formatRupiah formats the amount using the Indonesian thousand separator,
+// e.g. Rp 1.500.000.
+func formatRupiah(amount float64) string {
+	s := fmt.Sprintf("%.0f", amount)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return sign + "Rp " + b.String()
+}
+
+func wrapText(s string, width int) []string {
+	out := []string{}
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" && len(line)+len(word)+1 > width {
+			out = append(out, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		out = append(out, line)
+	}
+	return out
+}
@@ -0,0 +1,345 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+)
+
+func (u *usecase) GetPackagePrice(ctx context.Context, ID int, date time.Time) (*entity.PackagePrice, error) {
+	pkg, err := u.repo.GetPackageByID(ctx, ID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePackageNotFound, err)
+		}
+		return nil, err
+	}
+	venue, err := u.getVenueByCategoryID(ctx, pkg.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	today := civil.Today(civil.LoadLocation(venue.Timezone))
+	if date.IsZero() {
+		date = today
+	}
+	prices, err := u.getPackagePrices(ctx, []*entity.VenuePackage{pkg}, date, today)
+	if err != nil {
+		return nil, err
+	}
+	return prices[pkg.ID], nil
+}
+
+// getPackagePrices evaluates the price rules of packages belonging to the same
+// venue for the given date and returns the effective prices mapped by package
+// id. today is the current date in the venue timezone, used for lead time.
+func (u *usecase) getPackagePrices(ctx context.Context, packages []*entity.VenuePackage, date, today time.Time) (map[int]*entity.PackagePrice, error) {
+	out := map[int]*entity.PackagePrice{}
+	if len(packages) < 1 {
+		return out, nil
+	}
+
+	packageIDs := []int{}
+	for _, pkg := range packages {
+		packageIDs = append(packageIDs, pkg.ID)
+	}
+	rulesMappedByPackageID, err := u.repo.GetPriceRulesByPackageIDs(ctx, packageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range packages {
+		out[pkg.ID] = calculatePackagePrice(pkg, rulesMappedByPackageID[pkg.ID], civil.Date(date), today)
+	}
+	return out, nil
+}
+
+// calculatePackagePrice applies every matching rule on top of the package base
+// price, ordered by priority. Percentage adjustments are relative to the price
+// computed so far and override rules replace it entirely.
+func calculatePackagePrice(pkg *entity.VenuePackage, rules []*entity.PriceRule, date, today time.Time) *entity.PackagePrice {
+	out := &entity.PackagePrice{
+		PackageID:   pkg.ID,
+		Date:        date,
+		BasePrice:   pkg.Price,
+		Price:       pkg.Price,
+		Adjustments: []*entity.PriceAdjustment{},
+	}
+
+	leadDays := civil.DaysBetween(today, date)
+	for _, rule := range rules {
+		if !isPriceRuleMatch(rule, date, leadDays) {
+			continue
+		}
+
+		var amount float64
+		switch rule.AdjustmentType {
+		case entity.PriceAdjustmentPercentage:
+			amount = out.Price * rule.AdjustmentValue / 100
+		case entity.PriceAdjustmentFixed:
+			amount = rule.AdjustmentValue
+		case entity.PriceAdjustmentOverride:
+			amount = rule.AdjustmentValue - out.Price
+		default:
+			continue
+		}
+		amount = math.Round(amount*100) / 100
+		if out.Price+amount < 0 {
+			amount = -out.Price
+		}
+
+		out.Price += amount
+		out.Adjustments = append(out.Adjustments, &entity.PriceAdjustment{
+			RuleID: rule.ID,
+			Name:   rule.Name,
+			Type:   rule.AdjustmentType,
+			Amount: amount,
+		})
+	}
+	return out
+}
+
+func isPriceRuleMatch(rule *entity.PriceRule, date time.Time, leadDays int) bool {
+	switch rule.RuleType {
+	case entity.PriceRuleTypeDayOfWeek:
+		for _, d := range rule.DaysOfWeek {
+			if d == date.Weekday() {
+				return true
+			}
+		}
+		return false
+	case entity.PriceRuleTypeDateRange:
+		if rule.StartDate.IsZero() || rule.EndDate.IsZero() {
+			return false
+		}
+		return !date.Before(civil.Date(rule.StartDate)) && !date.After(civil.Date(rule.EndDate))
+	case entity.PriceRuleTypeSpecificDate:
+		if rule.StartDate.IsZero() {
+			return false
+		}
+		return date.Equal(civil.Date(rule.StartDate))
+	case entity.PriceRuleTypeLeadTime:
+		if leadDays < rule.MinLeadDays {
+			return false
+		}
+		return rule.MaxLeadDays == 0 || leadDays <= rule.MaxLeadDays
+	}
+	return false
+}
+
+// applyDatePricing recomputes the venue min and max price from the effective
+// package prices on the given date.
+func (u *usecase) applyDatePricing(ctx context.Context, venues []*entity.Venue, date time.Time) error {
+	if len(venues) < 1 {
+		return nil
+	}
+	venueIDs := []int{}
+	for _, vn := range venues {
+		venueIDs = append(venueIDs, vn.ID)
+	}
+
+	categories, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{
+		VenueIDs: venueIDs,
+	})
+	if err != nil {
+		return err
+	}
+	if len(categories) < 1 {
+		return nil
+	}
+	venueIDMappedByCategoryID := map[int]int{}
+	categoryIDs := []int{}
+	for _, ctg := range categories {
+		venueIDMappedByCategoryID[ctg.ID] = ctg.VenueID
+		categoryIDs = append(categoryIDs, ctg.ID)
+	}
+
+	packages, err := u.repo.GetVenuePackageByQuery(ctx, &entity.GetVenuePackageQuery{
+		CategoryIDs: categoryIDs,
+	})
+	if err != nil {
+		return err
+	}
+	if len(packages) < 1 {
+		return nil
+	}
+	packageIDs := []int{}
+	for _, pkg := range packages {
+		packageIDs = append(packageIDs, pkg.ID)
+	}
+	rulesMappedByPackageID, err := u.repo.GetPriceRulesByPackageIDs(ctx, packageIDs)
+	if err != nil {
+		return err
+	}
+
+	todayMappedByVenueID := map[int]time.Time{}
+	for _, vn := range venues {
+		todayMappedByVenueID[vn.ID] = civil.Today(civil.LoadLocation(vn.Timezone))
+	}
+	pricesMappedByVenueID := map[int][]float64{}
+	for _, pkg := range packages {
+		venueID := venueIDMappedByCategoryID[pkg.CategoryID]
+		price := calculatePackagePrice(pkg, rulesMappedByPackageID[pkg.ID], civil.Date(date), todayMappedByVenueID[venueID])
+		pricesMappedByVenueID[venueID] = append(pricesMappedByVenueID[venueID], price.Price)
+	}
+	for _, vn := range venues {
+		ps, ok := pricesMappedByVenueID[vn.ID]
+		if !ok {
+			continue
+		}
+		vn.MinPrice, vn.MaxPrice = ps[0], ps[0]
+		for _, p := range ps[1:] {
+			vn.MinPrice = math.Min(vn.MinPrice, p)
+			vn.MaxPrice = math.Max(vn.MaxPrice, p)
+		}
+	}
+	return nil
+}
+
+func (u *usecase) GetPriceRules(ctx context.Context, user *entity.CredentialClaim, packageID int) ([]*entity.PriceRule, error) {
+	if err := u.authorizePackage(ctx, user, packageID); err != nil {
+		return nil, err
+	}
+	rulesMappedByPackageID, err := u.repo.GetPriceRulesByPackageIDs(ctx, []int{packageID})
+	if err != nil {
+		return nil, err
+	}
+	out := rulesMappedByPackageID[packageID]
+	if out == nil {
+		out = []*entity.PriceRule{}
+	}
+	return out, nil
+}
+
+func (u *usecase) CreatePriceRule(ctx context.Context, user *entity.CredentialClaim, rule *entity.PriceRule) error {
+	if err := validatePriceRule(rule); err != nil {
+		return err
+	}
+	if err := u.authorizePackage(ctx, user, rule.PackageID); err != nil {
+		return err
+	}
+	return u.repo.CreatePriceRule(ctx, rule)
+}
+
+func (u *usecase) UpdatePriceRule(ctx context.Context, user *entity.CredentialClaim, rule *entity.PriceRule) error {
+	if err := validatePriceRule(rule); err != nil {
+		return err
+	}
+	existing, err := u.getOwnedPriceRule(ctx, user, rule.ID)
+	if err != nil {
+		return err
+	}
+	rule.PackageID = existing.PackageID
+	return u.repo.UpdatePriceRule(ctx, rule)
+}
+
+func (u *usecase) DeletePriceRule(ctx context.Context, user *entity.CredentialClaim, ID int) error {
+	if _, err := u.getOwnedPriceRule(ctx, user, ID); err != nil {
+		return err
+	}
+	return u.repo.DeletePriceRule(ctx, ID)
+}
+
+// getOwnedPriceRule returns the rule when its package belongs to a venue of
+// the user. Rules of other owners are reported as not found.
+func (u *usecase) getOwnedPriceRule(ctx context.Context, user *entity.CredentialClaim, ID int) (*entity.PriceRule, error) {
+	rule, err := u.repo.GetPriceRuleByID(ctx, ID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePriceRuleNotFound, err)
+		}
+		return nil, err
+	}
+	if err := u.authorizePackage(ctx, user, rule.PackageID); err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePriceRuleNotFound, err)
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// authorizePackage checks the package belongs to a venue of the user. A
+// package of another owner is reported as not found.
+func (u *usecase) authorizePackage(ctx context.Context, user *entity.CredentialClaim, packageID int) error {
+	pkg, err := u.repo.GetPackageByID(ctx, packageID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePackageNotFound, err)
+		}
+		return err
+	}
+	venue, err := u.getVenueByCategoryID(ctx, pkg.CategoryID)
+	if err != nil {
+		return err
+	}
+	if _, err := u.getOwnedVenueIDs(ctx, user, venue.ID); err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePackageNotFound, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// validatePriceRule checks the rule carries the fields isPriceRuleMatch reads
+// for its type, a rule missing them would never match.
+func validatePriceRule(rule *entity.PriceRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if !rule.StartDate.IsZero() {
+		rule.StartDate = civil.Date(rule.StartDate)
+	}
+	if !rule.EndDate.IsZero() {
+		rule.EndDate = civil.Date(rule.EndDate)
+	}
+
+	switch rule.RuleType {
+	case entity.PriceRuleTypeDayOfWeek:
+		if len(rule.DaysOfWeek) < 1 {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleDaysRequired, fmt.Errorf("day_of_week rule without days"))
+		}
+		for _, d := range rule.DaysOfWeek {
+			if d < time.Sunday || d > time.Saturday {
+				return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleDaysRequired, fmt.Errorf("invalid day of week %d", d))
+			}
+		}
+	case entity.PriceRuleTypeDateRange:
+		if rule.StartDate.IsZero() || rule.EndDate.IsZero() {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleDateRequired, fmt.Errorf("date_range rule without start or end date"))
+		}
+		if rule.EndDate.Before(rule.StartDate) {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRulePeriod, fmt.Errorf("date_range rule ends before it starts"))
+		}
+	case entity.PriceRuleTypeSpecificDate:
+		if rule.StartDate.IsZero() {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleDateRequired, fmt.Errorf("specific_date rule without start date"))
+		}
+	case entity.PriceRuleTypeLeadTime:
+		if rule.MinLeadDays < 0 || rule.MaxLeadDays < 0 || (rule.MaxLeadDays > 0 && rule.MaxLeadDays < rule.MinLeadDays) {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleLeadDays, fmt.Errorf("invalid lead days %d-%d", rule.MinLeadDays, rule.MaxLeadDays))
+		}
+	default:
+		return errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, fmt.Errorf("invalid rule type %q", rule.RuleType))
+	}
+
+	switch rule.AdjustmentType {
+	case entity.PriceAdjustmentPercentage:
+		if rule.AdjustmentValue < -100 {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleAdjustment, fmt.Errorf("percentage adjustment below -100"))
+		}
+	case entity.PriceAdjustmentFixed:
+	case entity.PriceAdjustmentOverride:
+		if rule.AdjustmentValue < 0 {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, entity.MsgPriceRuleAdjustment, fmt.Errorf("negative override price"))
+		}
+	default:
+		return errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePriceRuleInvalid, fmt.Errorf("invalid adjustment type %q", rule.AdjustmentType))
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+)
+
+func (u *usecase) ValidatePromo(ctx context.Context, order *entity.Order) error {
+	if strings.TrimSpace(order.PromoCode) == "" {
+		return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoCodeRequired, fmt.Errorf("promo code can't be empty"))
+	}
+	pkg, err := u.repo.GetPackageByID(ctx, order.PackageID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePackageNotFound, err)
+		}
+		return err
+	}
+	venue, err := u.getVenueByCategoryID(ctx, pkg.CategoryID)
+	if err != nil {
+		return err
+	}
+	order.Date = civil.Date(order.Date)
+	return u.priceOrder(ctx, pkg, venue, order)
+}
+
+func (u *usecase) CreatePromo(ctx context.Context, promo *entity.Promo) error {
+	if err := validatePromo(promo); err != nil {
+		return err
+	}
+	existing, err := u.repo.GetPromoByCode(ctx, promo.Code)
+	if err != nil && !errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+		return err
+	}
+	if existing != nil {
+		return errutil.NewWithCode(errutil.ErrConflict, entity.ErrCodePromoCodeTaken, fmt.Errorf("promo code %s already exists", promo.Code))
+	}
+	promo.UsedCount = 0
+	return u.repo.CreatePromo(ctx, promo)
+}
+
+func (u *usecase) UpdatePromo(ctx context.Context, promo *entity.Promo) error {
+	if err := validatePromo(promo); err != nil {
+		return err
+	}
+	_, err := u.repo.GetPromoByID(ctx, promo.ID)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePromoNotFound, err)
+		}
+		return err
+	}
+	existing, err := u.repo.GetPromoByCode(ctx, promo.Code)
+	if err != nil && !errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != promo.ID {
+		return errutil.NewWithCode(errutil.ErrConflict, entity.ErrCodePromoCodeTaken, fmt.Errorf("promo code %s already exists", promo.Code))
+	}
+	return u.repo.UpdatePromo(ctx, promo)
+}
+
+func (u *usecase) GetPromos(ctx context.Context, param entity.GetPromosParam) ([]*entity.Promo, *entity.Pagination, error) {
+	return u.repo.GetPromos(ctx, param)
+}
+
+func validatePromo(promo *entity.Promo) error {
+	promo.Code = strings.ToUpper(strings.TrimSpace(promo.Code))
+	if promo.Code == "" {
+		return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoCodeRequired, fmt.Errorf("promo code can't be empty"))
+	}
+	switch promo.DiscountType {
+	case entity.PromoDiscountPercentage:
+		if promo.DiscountValue > 100 {
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoPercentageTooHigh, fmt.Errorf("percentage discount above 100"))
+		}
+	case entity.PromoDiscountFixed:
+	default:
+		return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoDiscountType, fmt.Errorf("invalid discount type %q", promo.DiscountType))
+	}
+	if promo.DiscountValue <= 0 {
+		return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoDiscountNotPositive, fmt.Errorf("discount value must be positive"))
+	}
+	if promo.MaxDiscount < 0 || promo.MinSpend < 0 || promo.UsageLimit < 0 || promo.UsageLimitPerUser < 0 {
+		return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoNegativeLimit, fmt.Errorf("negative promo limit"))
+	}
+	if !promo.StartsAt.IsZero() && !promo.EndsAt.IsZero() && !promo.EndsAt.After(promo.StartsAt) {
+		return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInvalid, entity.MsgPromoPeriod, fmt.Errorf("promo ends before it starts"))
+	}
+	return nil
+}
+
+// getApplicablePromo checks every promo constraint except the atomic usage
+// claim, which is re-checked by the repository when the order is stored.
+func (u *usecase) getApplicablePromo(ctx context.Context, pkg *entity.VenuePackage, venue *entity.Venue, order *entity.Order, subtotal float64) (*entity.Promo, error) {
+	promo, err := u.repo.GetPromoByCode(ctx, order.PromoCode)
+	if err != nil {
+		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoNotFound, err)
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if !promo.IsActive || (!promo.StartsAt.IsZero() && now.Before(promo.StartsAt)) || (!promo.EndsAt.IsZero() && now.After(promo.EndsAt)) {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoInactive, fmt.Errorf("promo %s is not active", promo.Code))
+	}
+	if promo.PackageID > 0 && promo.PackageID != pkg.ID {
+		return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoNotApplicable, entity.MsgPromoNotForPackage, fmt.Errorf("promo %s not valid for package %d", promo.Code, pkg.ID))
+	}
+	if promo.VenueID > 0 && promo.VenueID != venue.ID {
+		return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoNotApplicable, entity.MsgPromoNotForVenue, fmt.Errorf("promo %s not valid for venue %d", promo.Code, venue.ID))
+	}
+	if promo.CityID > 0 && promo.CityID != venue.CityID {
+		return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePromoNotApplicable, entity.MsgPromoNotForCity, fmt.Errorf("promo %s not valid for city %d", promo.Code, venue.CityID))
+	}
+	if subtotal < promo.MinSpend {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoMinSpend, fmt.Errorf("subtotal below promo min spend"), promo.MinSpend)
+	}
+	if promo.UsageLimit > 0 && promo.UsedCount >= promo.UsageLimit {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoQuotaExhausted, fmt.Errorf("promo %s usage limit reached", promo.Code))
+	}
+	if promo.UsageLimitPerUser > 0 {
+		used, err := u.repo.CountPromoUsageByUser(ctx, promo.ID, order.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= promo.UsageLimitPerUser {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoUserLimit, fmt.Errorf("promo %s user limit reached", promo.Code))
+		}
+	}
+	return promo, nil
+}
+
+func calculateDiscount(promo *entity.Promo, subtotal float64) float64 {
+	var discount float64
+	switch promo.DiscountType {
+	case entity.PromoDiscountPercentage:
+		discount = subtotal * promo.DiscountValue / 100
+	case entity.PromoDiscountFixed:
+		discount = promo.DiscountValue
+	}
+	if promo.MaxDiscount > 0 {
+		discount = math.Min(discount, promo.MaxDiscount)
+	}
+	discount = math.Min(discount, subtotal)
+	return math.Round(discount*100) / 100
+}
+
+func (u *usecase) getVenueByCategoryID(ctx context.Context, categoryID int) (*entity.Venue, error) {
+	categories, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{
+		IDs: []int{categoryID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) < 1 {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeCategoryNotFound, fmt.Errorf("category not found"))
+	}
+	venues, _, err := u.repo.GetVenues(ctx, entity.GetVenuesParam{
+		ID:                  categories[0].VenueID,
+		IsWithoutPagination: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(venues) < 1 {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue not found"))
+	}
+	return venues[0], nil
+}
@@ -0,0 +1,213 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+)
+
+const defaultReportDays = 30
+
+type reportDefinition struct {
+	columns []*entity.ReportColumn
+	groups  []string
+	count   func(ctx context.Context, param *entity.ReportQuery) (int, error)
+	// stream calls fn with the values of every row in column order.
+	stream func(ctx context.Context, param *entity.ReportQuery, fn func(values []interface{}) error) error
+}
+
+func (u *usecase) reportDefinitions() map[string]*reportDefinition {
+	bookingGroups := []string{entity.ReportGroupByCity, entity.ReportGroupByVenue, entity.ReportGroupByDate, entity.ReportGroupByMonth}
+	groupColumns := []*entity.ReportColumn{
+		{Key: "groupKey", Title: "Group"},
+		{Key: "groupLabel", Title: "Name"},
+	}
+	bookingReport := func(values func(row *entity.BookingReportRow) []interface{}) func(ctx context.Context, param *entity.ReportQuery, fn func(values []interface{}) error) error {
+		return func(ctx context.Context, param *entity.ReportQuery, fn func(values []interface{}) error) error {
+			return u.repo.StreamBookingReport(ctx, param, func(row *entity.BookingReportRow) error {
+				return fn(append([]interface{}{row.GroupKey, row.GroupLabel}, values(row)...))
+			})
+		}
+	}
+
+	return map[string]*reportDefinition{
+		entity.ReportBookings: {
+			columns: append(groupColumns[:2:2],
+				&entity.ReportColumn{Key: "bookings", Title: "Bookings"},
+				&entity.ReportColumn{Key: "confirmed", Title: "Confirmed"},
+				&entity.ReportColumn{Key: "cancelled", Title: "Cancelled"},
+			),
+			groups: bookingGroups,
+			count:  u.repo.CountBookingReportGroups,
+			stream: bookingReport(func(row *entity.BookingReportRow) []interface{} {
+				return []interface{}{row.Bookings, row.Confirmed, row.Cancelled}
+			}),
+		},
+		entity.ReportRevenue: {
+			columns: append(groupColumns[:2:2],
+				&entity.ReportColumn{Key: "bookings", Title: "Confirmed Bookings"},
+				&entity.ReportColumn{Key: "gross", Title: "Gross"},
+				&entity.ReportColumn{Key: "discount", Title: "Discount"},
+				&entity.ReportColumn{Key: "tax", Title: "Tax"},
+				&entity.ReportColumn{Key: "revenue", Title: "Revenue"},
+			),
+			groups: bookingGroups,
+			count:  u.repo.CountBookingReportGroups,
+			stream: bookingReport(func(row *entity.BookingReportRow) []interface{} {
+				return []interface{}{row.Confirmed, row.Gross, row.Discount, row.Tax, row.Revenue}
+			}),
+		},
+		entity.ReportCancellations: {
+			columns: append(groupColumns[:2:2],
+				&entity.ReportColumn{Key: "bookings", Title: "Bookings"},
+				&entity.ReportColumn{Key: "cancelled", Title: "Cancelled"},
+				&entity.ReportColumn{Key: "cancellationRate", Title: "Cancellation Rate (%)"},
+			),
+			groups: bookingGroups,
+			count:  u.repo.CountBookingReportGroups,
+			stream: bookingReport(func(row *entity.BookingReportRow) []interface{} {
+				rate := 0.0
+				if row.Bookings > 0 {
+					rate = math.Round(float64(row.Cancelled)/float64(row.Bookings)*10000) / 100
+				}
+				return []interface{}{row.Bookings, row.Cancelled, rate}
+			}),
+		},
+		entity.ReportRegistrations: {
+			columns: []*entity.ReportColumn{
+				{Key: "groupKey", Title: "Period"},
+				{Key: "registrations", Title: "Registrations"},
+			},
+			groups: []string{entity.ReportGroupByDate, entity.ReportGroupByMonth},
+			count:  u.repo.CountRegistrationReportGroups,
+			stream: func(ctx context.Context, param *entity.ReportQuery, fn func(values []interface{}) error) error {
+				return u.repo.StreamRegistrationReport(ctx, param, func(row *entity.RegistrationReportRow) error {
+					return fn([]interface{}{row.GroupKey, row.Registrations})
+				})
+			},
+		},
+	}
+}
+
+// getReportDefinition validates the query against the report and fills in
+// its defaults. The end date given by the caller is inclusive, it is turned
+// into the exclusive bound used by the repository.
+func (u *usecase) getReportDefinition(name string, param *entity.ReportQuery) (*reportDefinition, error) {
+	def, ok := u.reportDefinitions()[name]
+	if !ok {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeReportNotFound, fmt.Errorf("unknown report %q", name))
+	}
+
+	if param.GroupBy == "" {
+		param.GroupBy = def.groups[0]
+	}
+	validGroup := false
+	for _, g := range def.groups {
+		if g == param.GroupBy {
+			validGroup = true
+			break
+		}
+	}
+	if !validGroup {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidGroupBy, fmt.Errorf("invalid groupBy %q for report %s", param.GroupBy, name))
+	}
+
+	if param.EndDate.IsZero() {
+		param.EndDate = civil.Today(time.UTC)
+	}
+	if param.StartDate.IsZero() {
+		param.StartDate = param.EndDate.AddDate(0, 0, -defaultReportDays+1)
+	}
+	if param.StartDate.After(param.EndDate) {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidDateRange, fmt.Errorf("startDate after endDate"))
+	}
+	param.StartDate = civil.Date(param.StartDate)
+	param.EndDate = civil.Date(param.EndDate).AddDate(0, 0, 1)
+	return def, nil
+}
+
+func (u *usecase) GetReport(ctx context.Context, name string, param *entity.ReportQuery) (*entity.Report, *entity.Pagination, error) {
+	def, err := u.getReportDefinition(name, param)
+	if err != nil {
+		return nil, nil, err
+	}
+	if param.Page <= 0 {
+		param.Page = 1
+	}
+
+	totalItems, err := def.count(ctx, param)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &entity.Report{
+		Name:    name,
+		Columns: def.columns,
+		Rows:    []map[string]interface{}{},
+	}
+	err = def.stream(ctx, param, func(values []interface{}) error {
+		row := map[string]interface{}{}
+		for i, col := range def.columns {
+			row[col.Key] = values[i]
+		}
+		report.Rows = append(report.Rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return report, &entity.Pagination{
+		Page:         param.Page,
+		TotalPage:    int(math.Ceil(float64(totalItems) / float64(param.Limit))),
+		CurrentItems: len(report.Rows),
+		TotalItems:   totalItems,
+	}, nil
+}
+
+// ExportReport writes the header and every row of the report to w as they are
+// read from the database, nothing is written when the query is invalid.
+func (u *usecase) ExportReport(ctx context.Context, name string, param *entity.ReportQuery, w entity.ReportWriter) error {
+	def, err := u.getReportDefinition(name, param)
+	if err != nil {
+		return err
+	}
+	param.Page, param.Limit = 0, 0
+
+	header := []string{}
+	for _, col := range def.columns {
+		header = append(header, col.Title)
+	}
+	if err := w.Write(header); err != nil {
+		return errutil.New(errutil.ErrInternal, fmt.Errorf("[ExportReport] err: %v", err))
+	}
+
+	record := make([]string, len(def.columns))
+	return def.stream(ctx, param, func(values []interface{}) error {
+		for i, v := range values {
+			record[i] = formatReportValue(v)
+		}
+		if err := w.Write(record); err != nil {
+			return errutil.New(errutil.ErrInternal, fmt.Errorf("[ExportReport] err: %v", err))
+		}
+		return nil
+	})
+}
+
+func formatReportValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case int:
+		return strconv.Itoa(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', 2, 64)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,247 @@
+package module
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedUsecase struct {
+	next Usecase
+}
+
+// NewTraced wraps every method of next in a span.
+func NewTraced(next Usecase) Usecase {
+	return &tracedUsecase{next: next}
+}
+
+func (t *tracedUsecase) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "usecase."+method)
+}
+
+// endSpan marks the span as failed only for server side errors, a request
+// rejected because of the client input is a normal outcome.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errutil.IsClientErr(err) {
+		cause := errutil.GetOriginalErr(err)
+		span.RecordError(cause)
+		span.SetStatus(codes.Error, cause.Error())
+	}
+	span.End()
+}
+
+func (t *tracedUsecase) GetVenues(ctx context.Context, param entity.GetVenuesParam) (_ []*entity.Venue, _ *entity.Pagination, err error) {
+	ctx, span := t.start(ctx, "GetVenues")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetVenues(ctx, param)
+}
+
+func (t *tracedUsecase) GetCities(ctx context.Context) (_ []*entity.City, err error) {
+	ctx, span := t.start(ctx, "GetCities")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetCities(ctx)
+}
+
+func (t *tracedUsecase) Register(ctx context.Context, payload *entity.User) (err error) {
+	ctx, span := t.start(ctx, "Register")
+	defer func() { endSpan(span, err) }()
+	return t.next.Register(ctx, payload)
+}
+
+func (t *tracedUsecase) Login(ctx context.Context, email, password string) (_ *entity.Auth, err error) {
+	ctx, span := t.start(ctx, "Login")
+	defer func() { endSpan(span, err) }()
+	return t.next.Login(ctx, email, password)
+}
+
+func (t *tracedUsecase) ValidateToken(ctx context.Context, token string) (_ *entity.CredentialClaim, err error) {
+	ctx, span := t.start(ctx, "ValidateToken")
+	defer func() { endSpan(span, err) }()
+	return t.next.ValidateToken(ctx, token)
+}
+
+func (t *tracedUsecase) UpdateLocale(ctx context.Context, user *entity.CredentialClaim, locale string) (_ *entity.Auth, err error) {
+	ctx, span := t.start(ctx, "UpdateLocale")
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdateLocale(ctx, user, locale)
+}
+
+func (t *tracedUsecase) Order(ctx context.Context, order *entity.Order) (err error) {
+	ctx, span := t.start(ctx, "Order")
+	defer func() { endSpan(span, err) }()
+	return t.next.Order(ctx, order)
+}
+
+func (t *tracedUsecase) GetPackagePrice(ctx context.Context, ID int, date time.Time) (_ *entity.PackagePrice, err error) {
+	ctx, span := t.start(ctx, "GetPackagePrice")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPackagePrice(ctx, ID, date)
+}
+
+func (t *tracedUsecase) GetPriceRules(ctx context.Context, user *entity.CredentialClaim, packageID int) (_ []*entity.PriceRule, err error) {
+	ctx, span := t.start(ctx, "GetPriceRules")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPriceRules(ctx, user, packageID)
+}
+
+func (t *tracedUsecase) CreatePriceRule(ctx context.Context, user *entity.CredentialClaim, rule *entity.PriceRule) (err error) {
+	ctx, span := t.start(ctx, "CreatePriceRule")
+	defer func() { endSpan(span, err) }()
+	return t.next.CreatePriceRule(ctx, user, rule)
+}
+
+func (t *tracedUsecase) UpdatePriceRule(ctx context.Context, user *entity.CredentialClaim, rule *entity.PriceRule) (err error) {
+	ctx, span := t.start(ctx, "UpdatePriceRule")
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdatePriceRule(ctx, user, rule)
+}
+
+func (t *tracedUsecase) DeletePriceRule(ctx context.Context, user *entity.CredentialClaim, ID int) (err error) {
+	ctx, span := t.start(ctx, "DeletePriceRule")
+	defer func() { endSpan(span, err) }()
+	return t.next.DeletePriceRule(ctx, user, ID)
+}
+
+func (t *tracedUsecase) ValidatePromo(ctx context.Context, order *entity.Order) (err error) {
+	ctx, span := t.start(ctx, "ValidatePromo")
+	defer func() { endSpan(span, err) }()
+	return t.next.ValidatePromo(ctx, order)
+}
+
+func (t *tracedUsecase) CreatePromo(ctx context.Context, promo *entity.Promo) (err error) {
+	ctx, span := t.start(ctx, "CreatePromo")
+	defer func() { endSpan(span, err) }()
+	return t.next.CreatePromo(ctx, promo)
+}
+
+func (t *tracedUsecase) UpdatePromo(ctx context.Context, promo *entity.Promo) (err error) {
+	ctx, span := t.start(ctx, "UpdatePromo")
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdatePromo(ctx, promo)
+}
+
+func (t *tracedUsecase) GetPromos(ctx context.Context, param entity.GetPromosParam) (_ []*entity.Promo, _ *entity.Pagination, err error) {
+	ctx, span := t.start(ctx, "GetPromos")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPromos(ctx, param)
+}
+
+func (t *tracedUsecase) GetOrderInvoice(ctx context.Context, ID int, user *entity.CredentialClaim) (_ *entity.File, err error) {
+	ctx, span := t.start(ctx, "GetOrderInvoice")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetOrderInvoice(ctx, ID, user)
+}
+
+func (t *tracedUsecase) MarkOrderPaid(ctx context.Context, ID int) (_ *entity.Order, err error) {
+	ctx, span := t.start(ctx, "MarkOrderPaid")
+	defer func() { endSpan(span, err) }()
+	return t.next.MarkOrderPaid(ctx, ID)
+}
+
+func (t *tracedUsecase) GetOrderCalendar(ctx context.Context, ID int, user *entity.CredentialClaim) (_ *entity.File, err error) {
+	ctx, span := t.start(ctx, "GetOrderCalendar")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetOrderCalendar(ctx, ID, user)
+}
+
+func (t *tracedUsecase) GetVenueCalendar(ctx context.Context, venueID int, token string) (_ *entity.File, err error) {
+	ctx, span := t.start(ctx, "GetVenueCalendar")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetVenueCalendar(ctx, venueID, token)
+}
+
+func (t *tracedUsecase) RotateVenueCalendarToken(ctx context.Context, venueID int) (_ string, err error) {
+	ctx, span := t.start(ctx, "RotateVenueCalendarToken")
+	defer func() { endSpan(span, err) }()
+	return t.next.RotateVenueCalendarToken(ctx, venueID)
+}
+
+func (t *tracedUsecase) GetOwnerVenues(ctx context.Context, user *entity.CredentialClaim) (_ []*entity.Venue, err error) {
+	ctx, span := t.start(ctx, "GetOwnerVenues")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetOwnerVenues(ctx, user)
+}
+
+func (t *tracedUsecase) GetOwnerOrders(ctx context.Context, user *entity.CredentialClaim, venueID int, param *entity.OwnerOrderQuery) (_ []*entity.OwnerOrder, _ *entity.Pagination, err error) {
+	ctx, span := t.start(ctx, "GetOwnerOrders")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetOwnerOrders(ctx, user, venueID, param)
+}
+
+func (t *tracedUsecase) GetVenueOccupancy(ctx context.Context, user *entity.CredentialClaim, venueID int, month time.Time) (_ []*entity.OccupancyDay, err error) {
+	ctx, span := t.start(ctx, "GetVenueOccupancy")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetVenueOccupancy(ctx, user, venueID, month)
+}
+
+func (t *tracedUsecase) GetVenueStats(ctx context.Context, user *entity.CredentialClaim, venueID int, startDate, endDate time.Time) (_ *entity.VenueStats, err error) {
+	ctx, span := t.start(ctx, "GetVenueStats")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetVenueStats(ctx, user, venueID, startDate, endDate)
+}
+
+func (t *tracedUsecase) AssignVenueOwner(ctx context.Context, venueID, userID int) (err error) {
+	ctx, span := t.start(ctx, "AssignVenueOwner")
+	defer func() { endSpan(span, err) }()
+	return t.next.AssignVenueOwner(ctx, venueID, userID)
+}
+
+func (t *tracedUsecase) RemoveVenueOwner(ctx context.Context, venueID, userID int) (err error) {
+	ctx, span := t.start(ctx, "RemoveVenueOwner")
+	defer func() { endSpan(span, err) }()
+	return t.next.RemoveVenueOwner(ctx, venueID, userID)
+}
+
+func (t *tracedUsecase) GetReport(ctx context.Context, name string, param *entity.ReportQuery) (_ *entity.Report, _ *entity.Pagination, err error) {
+	ctx, span := t.start(ctx, "GetReport")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetReport(ctx, name, param)
+}
+
+func (t *tracedUsecase) ExportReport(ctx context.Context, name string, param *entity.ReportQuery, w entity.ReportWriter) (err error) {
+	ctx, span := t.start(ctx, "ExportReport")
+	defer func() { endSpan(span, err) }()
+	return t.next.ExportReport(ctx, name, param, w)
+}
+
+func (t *tracedUsecase) ImportVenues(ctx context.Context, r io.Reader, param *entity.ImportParam) (_ *entity.ImportResult, err error) {
+	ctx, span := t.start(ctx, "ImportVenues")
+	defer func() { endSpan(span, err) }()
+	return t.next.ImportVenues(ctx, r, param)
+}
+
+func (t *tracedUsecase) GetVenuesNearby(ctx context.Context) (_ []*entity.VenueNearby, err error) {
+	ctx, span := t.start(ctx, "GetVenuesNearby")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetVenuesNearby(ctx)
+}
+
+func (t *tracedUsecase) GetVenueByID(ctx context.Context, ID int) (_ *entity.VenueDetail, err error) {
+	ctx, span := t.start(ctx, "GetVenueByID")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetVenueByID(ctx, ID)
+}
+
+func (t *tracedUsecase) GetPackageByID(ctx context.Context, ID int) (_ *entity.PackageDetail, err error) {
+	ctx, span := t.start(ctx, "GetPackageByID")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPackageByID(ctx, ID)
+}
+
+func (t *tracedUsecase) GetTranslations(ctx context.Context, contentType string, ID int) (_ *entity.ContentTranslations, err error) {
+	ctx, span := t.start(ctx, "GetTranslations")
+	defer func() { endSpan(span, err) }()
+	return t.next.GetTranslations(ctx, contentType, ID)
+}
+
+func (t *tracedUsecase) UpdateTranslations(ctx context.Context, contentType string, ID int, locale string, fields map[string]string) (_ *entity.ContentTranslations, err error) {
+	ctx, span := t.start(ctx, "UpdateTranslations")
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdateTranslations(ctx, contentType, ID, locale, fields)
+}
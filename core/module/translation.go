@@ -0,0 +1,213 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+)
+
+// GetTranslations returns the translations of a venue, category or package.
+func (u *usecase) GetTranslations(ctx context.Context, contentType string, ID int) (*entity.ContentTranslations, error) {
+	if err := u.checkContent(ctx, contentType, ID); err != nil {
+		return nil, err
+	}
+	translations, err := u.repo.GetTranslationsByQuery(ctx, &entity.GetTranslationQuery{
+		ContentType: contentType,
+		ContentIDs:  []int{ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &entity.ContentTranslations{
+		ContentType:  contentType,
+		ContentID:    ID,
+		Translations: map[string]map[string]string{},
+	}
+	for _, t := range translations {
+		if out.Translations[t.Locale] == nil {
+			out.Translations[t.Locale] = map[string]string{}
+		}
+		out.Translations[t.Locale][t.Field] = t.Value
+	}
+	return out, nil
+}
+
+// UpdateTranslations sets the translation in locale of the given fields of a
+// venue, category or package. An empty value removes the translation, the
+// field then falls back to the default locale.
+func (u *usecase) UpdateTranslations(ctx context.Context, contentType string, ID int, locale string, fields map[string]string) (*entity.ContentTranslations, error) {
+	if err := u.checkContent(ctx, contentType, ID); err != nil {
+		return nil, err
+	}
+	// The content columns hold the default locale, it is edited there.
+	if locale == i18n.Default || !i18n.IsSupported(locale) {
+		return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeLocaleNotSupported, fmt.Errorf("locale %q can't be translated", locale), strings.Join(translationLocales(), ", "))
+	}
+
+	allowed := entity.TranslatableFields[contentType]
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var details []errutil.Detail
+	translations := make([]*entity.Translation, 0, len(fields))
+	for _, name := range names {
+		if !contains(allowed, name) {
+			details = append(details, errutil.Detail{Field: name, Code: entity.ErrCodeFieldNotTranslatable, Args: []any{strings.Join(allowed, ", ")}})
+			continue
+		}
+		translations = append(translations, &entity.Translation{
+			ContentType: contentType,
+			ContentID:   ID,
+			Locale:      locale,
+			Field:       name,
+			Value:       strings.TrimSpace(fields[name]),
+		})
+	}
+	if len(details) > 0 {
+		return nil, errutil.WithDetails(errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeFieldNotTranslatable, fmt.Errorf("%d fields can't be translated", len(details))), details...)
+	}
+	if err := u.repo.SaveTranslations(ctx, translations); err != nil {
+		return nil, err
+	}
+	return u.GetTranslations(ctx, contentType, ID)
+}
+
+// checkContent makes sure the venue, category or package exists.
+func (u *usecase) checkContent(ctx context.Context, contentType string, ID int) error {
+	var found bool
+	var code string
+	switch contentType {
+	case entity.ContentVenue:
+		venues, _, err := u.repo.GetVenues(ctx, entity.GetVenuesParam{ID: ID, IsWithoutPagination: true})
+		if err != nil {
+			return err
+		}
+		found, code = len(venues) > 0, entity.ErrCodeVenueNotFound
+	case entity.ContentCategory:
+		categories, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{IDs: []int{ID}})
+		if err != nil {
+			return err
+		}
+		found, code = len(categories) > 0, entity.ErrCodeCategoryNotFound
+	case entity.ContentPackage:
+		packages, err := u.repo.GetVenuePackageByQuery(ctx, &entity.GetVenuePackageQuery{IDs: []int{ID}})
+		if err != nil {
+			return err
+		}
+		found, code = len(packages) > 0, entity.ErrCodePackageNotFound
+	default:
+		return errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("unknown content type %q", contentType))
+	}
+	if !found {
+		return errutil.NewWithCode(errutil.ErrGeneralNotFound, code, fmt.Errorf("%s %d not found", contentType, ID))
+	}
+	return nil
+}
+
+// translations returns the translations in the locale of ctx of the given
+// content, by ID then by field. There are none in the default locale.
+func (u *usecase) translations(ctx context.Context, contentType string, IDs []int) (map[int]map[string]string, error) {
+	locale := i18n.FromContext(ctx)
+	if locale == i18n.Default || len(IDs) == 0 {
+		return nil, nil
+	}
+	translations, err := u.repo.GetTranslationsByQuery(ctx, &entity.GetTranslationQuery{
+		ContentType: contentType,
+		ContentIDs:  IDs,
+		Locales:     []string{locale},
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := map[int]map[string]string{}
+	for _, t := range translations {
+		if out[t.ContentID] == nil {
+			out[t.ContentID] = map[string]string{}
+		}
+		out[t.ContentID][t.Field] = t.Value
+	}
+	return out, nil
+}
+
+// localizeVenues replaces the translatable fields of venues with their
+// translation in the locale of ctx, the missing ones keep the default locale.
+func (u *usecase) localizeVenues(ctx context.Context, venues []*entity.Venue) error {
+	IDs := make([]int, 0, len(venues))
+	for _, vn := range venues {
+		IDs = append(IDs, vn.ID)
+	}
+	translated, err := u.translations(ctx, entity.ContentVenue, IDs)
+	if err != nil {
+		return err
+	}
+	for _, vn := range venues {
+		if v, ok := translated[vn.ID][entity.FieldDescription]; ok {
+			vn.Description = v
+		}
+	}
+	return nil
+}
+
+func (u *usecase) localizeCategories(ctx context.Context, categories []*entity.VenuePackageCategory) error {
+	IDs := make([]int, 0, len(categories))
+	for _, ctg := range categories {
+		IDs = append(IDs, ctg.ID)
+	}
+	translated, err := u.translations(ctx, entity.ContentCategory, IDs)
+	if err != nil {
+		return err
+	}
+	for _, ctg := range categories {
+		if v, ok := translated[ctg.ID][entity.FieldDescription]; ok {
+			ctg.Description = v
+		}
+	}
+	return nil
+}
+
+func (u *usecase) localizePackages(ctx context.Context, packages []*entity.VenuePackage) error {
+	IDs := make([]int, 0, len(packages))
+	for _, pkg := range packages {
+		IDs = append(IDs, pkg.ID)
+	}
+	translated, err := u.translations(ctx, entity.ContentPackage, IDs)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range packages {
+		if v, ok := translated[pkg.ID][entity.FieldName]; ok {
+			pkg.Name = v
+		}
+		if v, ok := translated[pkg.ID][entity.FieldDescription]; ok {
+			pkg.Description = v
+		}
+	}
+	return nil
+}
+
+// translationLocales lists the locales translations can be written in.
+func translationLocales() []string {
+	out := []string{}
+	for _, l := range i18n.Supported {
+		if l != i18n.Default {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
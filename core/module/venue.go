@@ -4,11 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/faruqfadhil/venue-api/core/entity"
 	"github.com/faruqfadhil/venue-api/core/repository"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	"github.com/faruqfadhil/venue-api/pkg/config"
 	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+	"github.com/faruqfadhil/venue-api/pkg/worker"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Usecase interface {
@@ -17,23 +27,63 @@ type Usecase interface {
 	Register(ctx context.Context, payload *entity.User) error
 	Login(ctx context.Context, email, password string) (*entity.Auth, error)
 	ValidateToken(ctx context.Context, token string) (*entity.CredentialClaim, error)
+	UpdateLocale(ctx context.Context, user *entity.CredentialClaim, locale string) (*entity.Auth, error)
 	Order(ctx context.Context, order *entity.Order) error
+	GetPackagePrice(ctx context.Context, ID int, date time.Time) (*entity.PackagePrice, error)
+	GetPriceRules(ctx context.Context, user *entity.CredentialClaim, packageID int) ([]*entity.PriceRule, error)
+	CreatePriceRule(ctx context.Context, user *entity.CredentialClaim, rule *entity.PriceRule) error
+	UpdatePriceRule(ctx context.Context, user *entity.CredentialClaim, rule *entity.PriceRule) error
+	DeletePriceRule(ctx context.Context, user *entity.CredentialClaim, ID int) error
+	ValidatePromo(ctx context.Context, order *entity.Order) error
+	CreatePromo(ctx context.Context, promo *entity.Promo) error
+	UpdatePromo(ctx context.Context, promo *entity.Promo) error
+	GetPromos(ctx context.Context, param entity.GetPromosParam) ([]*entity.Promo, *entity.Pagination, error)
+	GetOrderInvoice(ctx context.Context, ID int, user *entity.CredentialClaim) (*entity.File, error)
+	MarkOrderPaid(ctx context.Context, ID int) (*entity.Order, error)
+	GetOrderCalendar(ctx context.Context, ID int, user *entity.CredentialClaim) (*entity.File, error)
+	GetVenueCalendar(ctx context.Context, venueID int, token string) (*entity.File, error)
+	RotateVenueCalendarToken(ctx context.Context, venueID int) (string, error)
+	GetOwnerVenues(ctx context.Context, user *entity.CredentialClaim) ([]*entity.Venue, error)
+	GetOwnerOrders(ctx context.Context, user *entity.CredentialClaim, venueID int, param *entity.OwnerOrderQuery) ([]*entity.OwnerOrder, *entity.Pagination, error)
+	GetVenueOccupancy(ctx context.Context, user *entity.CredentialClaim, venueID int, month time.Time) ([]*entity.OccupancyDay, error)
+	GetVenueStats(ctx context.Context, user *entity.CredentialClaim, venueID int, startDate, endDate time.Time) (*entity.VenueStats, error)
+	AssignVenueOwner(ctx context.Context, venueID, userID int) error
+	RemoveVenueOwner(ctx context.Context, venueID, userID int) error
+	GetReport(ctx context.Context, name string, param *entity.ReportQuery) (*entity.Report, *entity.Pagination, error)
+	ExportReport(ctx context.Context, name string, param *entity.ReportQuery, w entity.ReportWriter) error
+	ImportVenues(ctx context.Context, r io.Reader, param *entity.ImportParam) (*entity.ImportResult, error)
 	GetVenuesNearby(ctx context.Context) ([]*entity.VenueNearby, error)
 	GetVenueByID(ctx context.Context, ID int) (*entity.VenueDetail, error)
 	GetPackageByID(ctx context.Context, ID int) (*entity.PackageDetail, error)
+	GetTranslations(ctx context.Context, contentType string, ID int) (*entity.ContentTranslations, error)
+	UpdateTranslations(ctx context.Context, contentType string, ID int, locale string, fields map[string]string) (*entity.ContentTranslations, error)
 }
 
 type usecase struct {
-	repo repository.Repository
+	repo    repository.Repository
+	taxRate float64
+	workers *worker.Group
+	metrics *metrics.Metrics
 }
 
-func New(repo repository.Repository) Usecase {
-	return &usecase{repo: repo}
+func New(repo repository.Repository, cfg *config.Config, workers *worker.Group, m *metrics.Metrics) Usecase {
+	return &usecase{
+		repo:    repo,
+		taxRate: cfg.Order.TaxRate,
+		workers: workers,
+		metrics: m,
+	}
 }
 
 func (u *usecase) GetVenues(ctx context.Context, param entity.GetVenuesParam) ([]*entity.Venue, *entity.Pagination, error) {
+	// The database sorts on the stored prices while a date reprices the
+	// venues with their price rules, the order would not match the prices.
+	if !param.Date.IsZero() && strings.TrimPrefix(param.Sort, "-") == entity.VenueSortPrice {
+		return nil, nil, errutil.WithDetails(errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeSortNotSupported, fmt.Errorf("sort %q with a date", param.Sort)),
+			errutil.Detail{Field: "sort", Code: entity.ErrCodeSortNotSupported})
+	}
 	if !param.Date.IsZero() {
-		param.Date = time.Date(param.Date.Year(), param.Date.Month(), param.Date.Day(), 0, 0, 0, 0, time.UTC)
+		param.Date = civil.Date(param.Date)
 		existingOrders, err := u.repo.GetOrdersByDate(ctx, param.Date)
 		if err != nil {
 			return nil, nil, err
@@ -79,6 +129,20 @@ func (u *usecase) GetVenues(ctx context.Context, param entity.GetVenuesParam) ([
 	if err != nil {
 		return nil, nil, err
 	}
+	if !param.Date.IsZero() {
+		if err := u.applyDatePricing(ctx, venues, param.Date); err != nil {
+			return nil, nil, err
+		}
+	}
+	// Only the fields asked are worth fetching.
+	wants := func(field string) bool {
+		return len(param.Fields) == 0 || contains(param.Fields, field)
+	}
+	if wants("name") || wants("description") {
+		if err := u.localizeVenues(ctx, venues); err != nil {
+			return nil, nil, err
+		}
+	}
 	venueIDs := []int{}
 	venuesMappedByCityID := map[int][]*entity.Venue{}
 	for _, vn := range venues {
@@ -87,7 +151,7 @@ func (u *usecase) GetVenues(ctx context.Context, param entity.GetVenuesParam) ([
 	}
 
 	// Map city
-	if len(venuesMappedByCityID) > 0 {
+	if len(venuesMappedByCityID) > 0 && wants("city") {
 		cities, err := u.repo.GetCities(ctx)
 		if err != nil {
 			return nil, nil, err
@@ -102,7 +166,7 @@ func (u *usecase) GetVenues(ctx context.Context, param entity.GetVenuesParam) ([
 	}
 
 	// Map gallery
-	if len(venueIDs) > 0 {
+	if len(venueIDs) > 0 && wants("gallery") {
 		galleryMappedByVenueID, err := u.repo.GetGalleriesByVenueIDs(ctx, venueIDs)
 		if err != nil {
 			return nil, nil, err
@@ -127,8 +191,9 @@ func (u *usecase) Register(ctx context.Context, payload *entity.User) error {
 		return err
 	}
 	if existingUser != nil {
-		return errutil.New(errutil.ErrGeneralBadRequest, err, "Email sudah terdaftar di sistem")
+		return errutil.WithDetails(errutil.NewWithCode(errutil.ErrConflict, entity.ErrCodeEmailTaken, err), errutil.Detail{Field: "email", Code: entity.ErrCodeEmailTaken})
 	}
+	payload.Role = entity.RoleCustomer
 	return u.repo.Register(ctx, payload)
 }
 
@@ -137,7 +202,8 @@ func (u *usecase) Login(ctx context.Context, email, password string) (*entity.Au
 	if err != nil {
 		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
 			// Unauthorized.
-			return nil, errutil.New(errutil.ErrUnauthorized, err, "Username atau password salah")
+			u.metrics.LoginFailed()
+			return nil, errutil.NewWithCode(errutil.ErrUnauthorized, entity.ErrCodeInvalidCredentials, err)
 		}
 		return nil, err
 	}
@@ -148,25 +214,145 @@ func (u *usecase) ValidateToken(ctx context.Context, token string) (*entity.Cred
 	return u.repo.ValidateToken(ctx, token)
 }
 
+// UpdateLocale saves the preferred locale of user, empty to follow the
+// Accept-Language header, and issues a token carrying it.
+func (u *usecase) UpdateLocale(ctx context.Context, user *entity.CredentialClaim, locale string) (*entity.Auth, error) {
+	if err := u.repo.UpdateUserLocale(ctx, user.ID, locale); err != nil {
+		return nil, err
+	}
+	account, err := u.repo.FindUserByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.IssueToken(ctx, account)
+}
+
 func (u *usecase) Order(ctx context.Context, order *entity.Order) error {
-	_, err := u.repo.GetPackageByID(ctx, order.PackageID)
+	order.Date = civil.Date(order.Date)
+	pkg, err := u.repo.GetPackageByID(ctx, order.PackageID)
 	if err != nil {
 		if errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
-			return errutil.New(errutil.ErrGeneralBadRequest, err, fmt.Sprintf("Tidak dapat membuat order untuk tanggal %v dikarenakan package id %d tidak ditemukan", order.Date, order.PackageID))
+			u.metrics.OrderRejected(metrics.OrderRejectedPackageNotFound)
+			return errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodePackageNotFound, entity.MsgOrderPackageNotFound, err, order.Date.Format(civil.Layout), order.PackageID)
 		}
 		return err
 	}
+	venue, err := u.getVenueByCategoryID(ctx, pkg.CategoryID)
+	if err != nil {
+		return err
+	}
+	// The booked date is a day at the venue, so "today" must be evaluated in
+	// the venue timezone and not in the server or client one.
+	if order.Date.Before(civil.Today(civil.LoadLocation(venue.Timezone))) {
+		u.metrics.OrderRejected(metrics.OrderRejectedPastDate)
+		return errutil.WithDetails(errutil.NewWithCode(errutil.ErrUnprocessable, entity.ErrCodeDateInPast, fmt.Errorf("date in the past"), order.Date.Format(civil.Layout)), errutil.Detail{Field: "date", Code: entity.ErrCodeDateInPast})
+	}
 
-	order.Date = time.Date(order.Date.Year(), order.Date.Month(), order.Date.Day(), 0, 0, 0, 0, time.UTC)
 	existingOrder, err := u.repo.GetOrderByPackageIDAndDate(ctx, order.PackageID, order.Date)
 	if err != nil && !errors.Is(errutil.GetTypeErr(err), errutil.ErrGeneralNotFound) {
 		return err
 	}
 	if existingOrder != nil {
-		return errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("unavailable date"), fmt.Sprintf("Tidak dapat membuat order untuk tanggal %v dikarenakan tempat sudah di reservasi", order.Date))
+		u.metrics.OrderRejected(metrics.OrderRejectedUnavailableDate)
+		return errutil.WithDetails(errutil.NewWithCode(errutil.ErrConflict, entity.ErrCodeDateUnavailable, fmt.Errorf("unavailable date"), order.Date.Format(civil.Layout)), errutil.Detail{Field: "date", Code: entity.ErrCodeDateUnavailable})
+	}
+
+	if err := u.priceOrder(ctx, pkg, venue, order); err != nil {
+		return err
+	}
+
+	if err := u.repo.CreateOrder(ctx, order); err != nil {
+		return err
 	}
+	u.metrics.OrderCreated()
+	return nil
+}
 
-	return u.repo.CreateOrder(ctx, order)
+// priceOrder computes the package, add-on, discount and total price of the
+// order on the server side, the client only sends what it wants to book.
+func (u *usecase) priceOrder(ctx context.Context, pkg *entity.VenuePackage, venue *entity.Venue, order *entity.Order) error {
+	addons, err := u.buildOrderAddons(ctx, pkg.ID, order.Addons)
+	if err != nil {
+		return err
+	}
+	prices, err := u.getPackagePrices(ctx, []*entity.VenuePackage{pkg}, order.Date, civil.Today(civil.LoadLocation(venue.Timezone)))
+	if err != nil {
+		return err
+	}
+	order.Addons = addons
+	order.PackagePrice = prices[pkg.ID].Price
+	order.AddonPrice = 0
+	for _, ad := range addons {
+		order.AddonPrice += ad.Subtotal
+	}
+	subtotal := order.PackagePrice + order.AddonPrice
+
+	order.PromoID = 0
+	order.Discount = 0
+	if strings.TrimSpace(order.PromoCode) != "" {
+		promo, err := u.getApplicablePromo(ctx, pkg, venue, order, subtotal)
+		if err != nil {
+			return err
+		}
+		order.PromoID = promo.ID
+		order.PromoCode = promo.Code
+		order.Discount = calculateDiscount(promo, subtotal)
+	}
+	order.Tax = math.Round((subtotal-order.Discount)*u.taxRate*100) / 100
+	order.TotalPrice = subtotal - order.Discount + order.Tax
+	return nil
+}
+
+// buildOrderAddons validates the requested add-ons against the package and
+// fills in the name and price snapshot from the catalog, so the client can
+// only choose which add-on and how many.
+func (u *usecase) buildOrderAddons(ctx context.Context, packageID int, requested []*entity.OrderAddon) ([]*entity.OrderAddon, error) {
+	if len(requested) < 1 {
+		return nil, nil
+	}
+
+	addons, err := u.repo.GetPackageAddonsByQuery(ctx, &entity.GetPackageAddonQuery{
+		PackageIDs: []int{packageID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	addonMappedByID := map[int]*entity.PackageAddon{}
+	for _, ad := range addons {
+		addonMappedByID[ad.ID] = ad
+	}
+
+	quantityMappedByAddonID := map[int]int{}
+	addonIDs := []int{}
+	for _, req := range requested {
+		if _, ok := addonMappedByID[req.AddonID]; !ok {
+			return nil, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeAddonUnavailable, fmt.Errorf("addon %d not found in package %d", req.AddonID, packageID), req.AddonID)
+		}
+		if _, ok := quantityMappedByAddonID[req.AddonID]; !ok {
+			addonIDs = append(addonIDs, req.AddonID)
+		}
+		quantityMappedByAddonID[req.AddonID] += req.Quantity
+	}
+
+	out := []*entity.OrderAddon{}
+	for _, id := range addonIDs {
+		ad := addonMappedByID[id]
+		qty := quantityMappedByAddonID[id]
+		if qty < ad.MinQuantity {
+			return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeAddonQuantity, entity.MsgAddonQuantityBelowMin, fmt.Errorf("addon %d quantity %d below minimum %d", id, qty, ad.MinQuantity), ad.Name, ad.MinQuantity, ad.Unit)
+		}
+		if ad.MaxQuantity > 0 && qty > ad.MaxQuantity {
+			return nil, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeAddonQuantity, entity.MsgAddonQuantityAboveMax, fmt.Errorf("addon %d quantity %d above maximum %d", id, qty, ad.MaxQuantity), ad.Name, ad.MaxQuantity, ad.Unit)
+		}
+		out = append(out, &entity.OrderAddon{
+			AddonID:   ad.ID,
+			Name:      ad.Name,
+			UnitPrice: ad.UnitPrice,
+			Quantity:  qty,
+			Subtotal:  ad.UnitPrice * float64(qty),
+		})
+	}
+	return out, nil
 }
 
 func (u *usecase) GetVenuesNearby(ctx context.Context) ([]*entity.VenueNearby, error) {
@@ -222,8 +408,19 @@ func (u *usecase) GetVenueByID(ctx context.Context, ID int) (*entity.VenueDetail
 	}
 
 	if len(venues) < 1 {
-		return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("venue not found"), "venue tidak ditemukan")
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue not found"))
 	}
+	// View statistics must never slow down or break the detail page, so the
+	// view is recorded in the background and a failure is only logged.
+	today := civil.Today(civil.LoadLocation(venues[0].Timezone))
+	log := logger.FromContext(ctx)
+	spanCtx := trace.SpanContextFromContext(ctx)
+	u.workers.Go(func(ctx context.Context) {
+		ctx = trace.ContextWithSpanContext(ctx, spanCtx)
+		if err := u.repo.IncrementVenueView(ctx, ID, today); err != nil {
+			log.WarnContext(ctx, "unable to record venue view", slog.Int("venue_id", ID), slog.String("error", err.Error()))
+		}
+	})
 
 	categories, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{
 		VenueID: ID,
@@ -232,9 +429,15 @@ func (u *usecase) GetVenueByID(ctx context.Context, ID int) (*entity.VenueDetail
 		return nil, err
 	}
 
+	if err := u.localizeCategories(ctx, categories); err != nil {
+		return nil, err
+	}
+
+	updatedAt := venues[0].UpdatedAt
 	categoryIDs := []int{}
 	for _, ctg := range categories {
 		categoryIDs = append(categoryIDs, ctg.ID)
+		updatedAt = latest(updatedAt, ctg.UpdatedAt)
 	}
 
 	if len(categoryIDs) > 0 {
@@ -244,9 +447,13 @@ func (u *usecase) GetVenueByID(ctx context.Context, ID int) (*entity.VenueDetail
 		if err != nil {
 			return nil, err
 		}
+		if err := u.localizePackages(ctx, packages); err != nil {
+			return nil, err
+		}
 		packagesMappedByCategoryID := map[int][]*entity.VenuePackage{}
 		for _, pkg := range packages {
 			packagesMappedByCategoryID[pkg.CategoryID] = append(packagesMappedByCategoryID[pkg.CategoryID], pkg)
+			updatedAt = latest(updatedAt, pkg.UpdatedAt)
 		}
 
 		for _, ctg := range categories {
@@ -266,7 +473,9 @@ func (u *usecase) GetVenueByID(ctx context.Context, ID int) (*entity.VenueDetail
 		Instagram:   venues[0].Instagram,
 		Address:     venues[0].Address,
 		Logo:        venues[0].Logo,
+		Timezone:    venues[0].Timezone,
 		Categories:  categories,
+		UpdatedAt:   updatedAt,
 	}, nil
 }
 
@@ -278,7 +487,10 @@ func (u *usecase) GetPackageByID(ctx context.Context, ID int) (*entity.PackageDe
 		return nil, err
 	}
 	if len(pkg) < 1 {
-		return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("package not found"), "package tidak ditemukan")
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodePackageNotFound, fmt.Errorf("package not found"))
+	}
+	if err := u.localizePackages(ctx, pkg); err != nil {
+		return nil, err
 	}
 	category, err := u.repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{
 		IDs: []int{pkg[0].CategoryID},
@@ -287,18 +499,24 @@ func (u *usecase) GetPackageByID(ctx context.Context, ID int) (*entity.PackageDe
 		return nil, err
 	}
 	if len(category) < 1 {
-		return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("category not found"), "category tidak ditemukan")
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeCategoryNotFound, fmt.Errorf("category not found"))
 	}
 	venue, _, err := u.GetVenues(ctx, entity.GetVenuesParam{
-		ID: category[0].VenueID,
+		ID:                  category[0].VenueID,
+		IsWithoutPagination: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 	if len(venue) < 1 {
-		return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("venue not found"), "venue tidak ditemukan")
+		return nil, errutil.NewWithCode(errutil.ErrGeneralNotFound, entity.ErrCodeVenueNotFound, fmt.Errorf("venue not found"))
+	}
+	addons, err := u.repo.GetPackageAddonsByQuery(ctx, &entity.GetPackageAddonQuery{
+		PackageIDs: []int{pkg[0].ID},
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return &entity.PackageDetail{
 		ID:           pkg[0].ID,
 		ThumbnailURL: pkg[0].ThumbnailURL,
@@ -309,5 +527,17 @@ func (u *usecase) GetPackageByID(ctx context.Context, ID int) (*entity.PackageDe
 		VenuePhone:   venue[0].Phone,
 		Gallery:      venue[0].Gallery,
 		Description:  pkg[0].Description,
+		Addons:       addons,
 	}, nil
 }
+
+// latest returns the most recent of times.
+func latest(times ...time.Time) time.Time {
+	var out time.Time
+	for _, t := range times {
+		if t.After(out) {
+			out = t
+		}
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package module
+
+import (
+	"context"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/core/repository"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+)
+
+// orderRepo serves a single package of a single venue, every date is already
+// booked so Order stops right after the past date check.
+type orderRepo struct {
+	repository.Repository
+	timezone   string
+	lookedUpAt time.Time
+}
+
+func (r *orderRepo) GetPackageByID(ctx context.Context, ID int) (*entity.VenuePackage, error) {
+	return &entity.VenuePackage{ID: ID, CategoryID: 2, Price: 1000}, nil
+}
+
+func (r *orderRepo) GetVenueCategoryPackageByQuery(ctx context.Context, param *entity.GetVenueCategoryByQuery) ([]*entity.VenuePackageCategory, error) {
+	return []*entity.VenuePackageCategory{{ID: 2, VenueID: 3}}, nil
+}
+
+func (r *orderRepo) GetVenues(ctx context.Context, param entity.GetVenuesParam) ([]*entity.Venue, *entity.Pagination, error) {
+	return []*entity.Venue{{ID: 3, Timezone: r.timezone}}, &entity.Pagination{}, nil
+}
+
+func (r *orderRepo) GetOrderByPackageIDAndDate(ctx context.Context, packageID int, date time.Time) (*entity.Order, error) {
+	r.lookedUpAt = date
+	return &entity.Order{ID: 1, PackageID: packageID, Date: date}, nil
+}
+
+func TestOrderPastDate(t *testing.T) {
+	// Kiritimati (UTC+14) is always a day ahead of Pago Pago (UTC-11), so
+	// today there is yesterday here whatever the time of the test.
+	kiritimati := civil.LoadLocation("Pacific/Kiritimati")
+	pagoPago := civil.LoadLocation("Pacific/Pago_Pago")
+	jakarta := civil.LoadLocation("Asia/Jakarta")
+	tests := []struct {
+		name     string
+		timezone string
+		date     time.Time
+		wantCode string
+	}{
+		{"yesterday in the venue", "Asia/Jakarta", civil.Today(jakarta).AddDate(0, 0, -1), entity.ErrCodeDateInPast},
+		{"today in the venue", "Asia/Jakarta", civil.Today(jakarta), entity.ErrCodeDateUnavailable},
+		{"tomorrow in the venue", "Asia/Makassar", civil.Today(civil.LoadLocation("Asia/Makassar")).AddDate(0, 0, 1), entity.ErrCodeDateUnavailable},
+		{"today of a zone behind the venue", "Pacific/Kiritimati", civil.Today(pagoPago), entity.ErrCodeDateInPast},
+		{"today of a zone ahead of the venue", "Pacific/Pago_Pago", civil.Today(kiritimati), entity.ErrCodeDateUnavailable},
+		{"empty timezone is wib", "", civil.Today(jakarta).AddDate(0, 0, -1), entity.ErrCodeDateInPast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &orderRepo{timezone: tt.timezone}
+			u := &usecase{repo: repo, metrics: metrics.New()}
+			err := u.Order(context.Background(), &entity.Order{PackageID: 1, UserID: 1, Date: tt.date})
+			if got := errutil.GetCode(err); got != tt.wantCode {
+				t.Fatalf("Order(%s) code = %q, want %q (err: %v)", tt.date.Format(civil.Layout), got, tt.wantCode, err)
+			}
+			if tt.wantCode == entity.ErrCodeDateUnavailable && !repo.lookedUpAt.Equal(tt.date) {
+				t.Errorf("looked up %v, want %v", repo.lookedUpAt, tt.date)
+			}
+		})
+	}
+}
+
+func TestOrderDateIsCivil(t *testing.T) {
+	// 00:30 WIB on the 11th is still the 10th in UTC, the date booked is the
+	// day the client picked, not the UTC one.
+	jakarta := civil.LoadLocation("Asia/Jakarta")
+	picked := civil.Today(jakarta).AddDate(0, 0, 30)
+	sent := time.Date(picked.Year(), picked.Month(), picked.Day(), 0, 30, 0, 0, jakarta)
+
+	repo := &orderRepo{timezone: "Asia/Jakarta"}
+	u := &usecase{repo: repo, metrics: metrics.New()}
+	order := &entity.Order{PackageID: 1, UserID: 1, Date: sent}
+	err := u.Order(context.Background(), order)
+	if got := errutil.GetCode(err); got != entity.ErrCodeDateUnavailable {
+		t.Fatalf("Order code = %q, want %q (err: %v)", got, entity.ErrCodeDateUnavailable, err)
+	}
+	if !order.Date.Equal(picked) || order.Date.Location() != time.UTC {
+		t.Errorf("order date = %v, want %v", order.Date, picked)
+	}
+	if !repo.lookedUpAt.Equal(picked) {
+		t.Errorf("looked up %v, want %v", repo.lookedUpAt, picked)
+	}
+}
@@ -11,16 +11,56 @@ type Repository interface {
 	Register(ctx context.Context, payload *entity.User) error
 	Login(ctx context.Context, email, password string) (*entity.Auth, error)
 	FindUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindUserByID(ctx context.Context, ID int) (*entity.User, error)
+	FindUsersByIDs(ctx context.Context, IDs []int) ([]*entity.User, error)
 	ValidateToken(ctx context.Context, token string) (*entity.CredentialClaim, error)
+	IssueToken(ctx context.Context, user *entity.User) (*entity.Auth, error)
+	UpdateUserLocale(ctx context.Context, userID int, locale string) error
 
 	GetVenues(ctx context.Context, param entity.GetVenuesParam) ([]*entity.Venue, *entity.Pagination, error)
 	GetCities(ctx context.Context) ([]*entity.City, error)
 
 	GetOrderByPackageIDAndDate(ctx context.Context, packageID int, date time.Time) (*entity.Order, error)
 	CreateOrder(ctx context.Context, order *entity.Order) error
+	GetOrderByID(ctx context.Context, ID int) (*entity.Order, error)
 	GetPackageByID(ctx context.Context, ID int) (*entity.VenuePackage, error)
 	GetGalleriesByVenueIDs(ctx context.Context, IDs []int) (map[int][]string, error)
 	GetOrdersByDate(ctx context.Context, date time.Time) ([]*entity.Order, error)
+	GetOrdersByQuery(ctx context.Context, param *entity.GetOrderQuery) ([]*entity.Order, error)
+	UpdateVenueCalendarToken(ctx context.Context, venueID int, token string) error
+	MarkOrderPaid(ctx context.Context, ID int, paidAt time.Time) error
 	GetVenuePackageByQuery(ctx context.Context, param *entity.GetVenuePackageQuery) ([]*entity.VenuePackage, error)
 	GetVenueCategoryPackageByQuery(ctx context.Context, param *entity.GetVenueCategoryByQuery) ([]*entity.VenuePackageCategory, error)
+	GetPackageAddonsByQuery(ctx context.Context, param *entity.GetPackageAddonQuery) ([]*entity.PackageAddon, error)
+	GetPriceRulesByPackageIDs(ctx context.Context, IDs []int) (map[int][]*entity.PriceRule, error)
+	GetPriceRuleByID(ctx context.Context, ID int) (*entity.PriceRule, error)
+	CreatePriceRule(ctx context.Context, rule *entity.PriceRule) error
+	UpdatePriceRule(ctx context.Context, rule *entity.PriceRule) error
+	DeletePriceRule(ctx context.Context, ID int) error
+
+	CreatePromo(ctx context.Context, promo *entity.Promo) error
+	UpdatePromo(ctx context.Context, promo *entity.Promo) error
+	GetPromoByID(ctx context.Context, ID int) (*entity.Promo, error)
+	GetPromoByCode(ctx context.Context, code string) (*entity.Promo, error)
+	GetPromos(ctx context.Context, param entity.GetPromosParam) ([]*entity.Promo, *entity.Pagination, error)
+	CountPromoUsageByUser(ctx context.Context, promoID, userID int) (int, error)
+
+	GetVenueIDsByOwnerID(ctx context.Context, userID int) ([]int, error)
+	AssignVenueOwner(ctx context.Context, venueID, userID int) error
+	RemoveVenueOwner(ctx context.Context, venueID, userID int) error
+	IncrementVenueView(ctx context.Context, venueID int, date time.Time) error
+	GetOwnerOrders(ctx context.Context, param *entity.OwnerOrderQuery) ([]*entity.OwnerOrder, *entity.Pagination, error)
+	GetMonthlyBookingStats(ctx context.Context, param *entity.VenueStatsQuery) ([]*entity.MonthlyBookingStat, error)
+	GetTopPackageStats(ctx context.Context, param *entity.VenueStatsQuery, limit int) ([]*entity.PackageBookingStat, error)
+	CountVenueViews(ctx context.Context, param *entity.VenueStatsQuery) (int, error)
+
+	StreamBookingReport(ctx context.Context, param *entity.ReportQuery, fn func(row *entity.BookingReportRow) error) error
+	CountBookingReportGroups(ctx context.Context, param *entity.ReportQuery) (int, error)
+	StreamRegistrationReport(ctx context.Context, param *entity.ReportQuery, fn func(row *entity.RegistrationReportRow) error) error
+	CountRegistrationReportGroups(ctx context.Context, param *entity.ReportQuery) (int, error)
+
+	ImportVenues(ctx context.Context, data *entity.VenueImport, param *entity.ImportParam) (*entity.ImportResult, error)
+
+	GetTranslationsByQuery(ctx context.Context, param *entity.GetTranslationQuery) ([]*entity.Translation, error)
+	SaveTranslations(ctx context.Context, translations []*entity.Translation) error
 }
@@ -0,0 +1,11 @@
+// Package db embeds the SQL files of the schema migrations and the optional
+// sample data, both are applied by pkg/migrate.
+package db
+
+import "embed"
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+//go:embed seeds/*.sql
+var Seeds embed.FS
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS `venue_view_stat`;
+DROP TABLE IF EXISTS `venue_owner`;
+DROP TABLE IF EXISTS `promo_usage`;
+DROP TABLE IF EXISTS `promo`;
+DROP TABLE IF EXISTS `order_addon`;
+DROP TABLE IF EXISTS `order`;
+DROP TABLE IF EXISTS `auth`;
+DROP TABLE IF EXISTS `package_price_rule`;
+DROP TABLE IF EXISTS `package_addon`;
+DROP TABLE IF EXISTS `category_package`;
+DROP TABLE IF EXISTS `venue_category_package`;
+DROP TABLE IF EXISTS `venue_gallery`;
+DROP TABLE IF EXISTS `city`;
+DROP TABLE IF EXISTS `venue`;
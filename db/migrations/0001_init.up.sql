@@ -0,0 +1,232 @@
+CREATE TABLE IF NOT EXISTS `venue` (
+  `id` int(11) NOT NULL AUTO_INCREMENT COMMENT 'venue identifier',
+  `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source',
+  `name` TEXT NOT NULL,
+  `min_price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `max_price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `capacity` int(11) NOT NULL DEFAULT 0,
+  `star` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `review_count` int(11) NOT NULL DEFAULT 0,
+  `thumbnail_url` TEXT NOT NULL,
+  `city_id` int(11) NOT NULL,
+  `description` TEXT NOT NULL,
+  `website` TEXT NOT NULL,
+  `phone` varchar(13) NOT NULL DEFAULT '',
+  `email` varchar(255) NOT NULL DEFAULT '',
+  `instagram` varchar(255) NOT NULL DEFAULT '',
+  `address` TEXT NOT NULL,
+  `logo` TEXT NOT NULL,
+  `is_favourite` TINYINT(1) NOT NULL DEFAULT 0,
+  `timezone` varchar(64) NOT NULL DEFAULT 'Asia/Jakarta' COMMENT 'IANA timezone, booking dates are days in this zone',
+  `calendar_token` varchar(64) NOT NULL DEFAULT '' COMMENT 'secret token of the venue calendar feed',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_venue_external_id` (`external_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `city` (
+  `id` int(11) NOT NULL AUTO_INCREMENT COMMENT 'city identifier',
+  `name` TEXT NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `venue_gallery` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source',
+  `venue_id` int(11) NOT NULL,
+  `file_url` TEXT NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_venue_gallery_external_id` (`external_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+
+CREATE TABLE IF NOT EXISTS `venue_category_package` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source',
+  `venue_id` int(11) NOT NULL,
+  `description` TEXT NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_venue_category_package_external_id` (`external_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `category_package` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source',
+  `category_id` int(11) NOT NULL,
+  `name` TEXT NOT NULL,
+  `thumbnail_url` TEXT NOT NULL,
+  `description` TEXT NOT NULL,
+  `price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `capacity` int(11) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_category_package_external_id` (`external_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `package_addon` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `package_id` int(11) NOT NULL,
+  `name` TEXT NOT NULL,
+  `description` TEXT NOT NULL,
+  `unit` varchar(50) NOT NULL DEFAULT '' COMMENT 'e.g. porsi, jam, orang',
+  `unit_price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `min_quantity` int(11) NOT NULL DEFAULT 1,
+  `max_quantity` int(11) NOT NULL DEFAULT 0 COMMENT '0 means unlimited',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `package_price_rule` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `package_id` int(11) NOT NULL,
+  `name` varchar(255) NOT NULL DEFAULT '',
+  `rule_type` varchar(20) NOT NULL COMMENT 'day_of_week, date_range, specific_date or lead_time',
+  `days_of_week` varchar(20) NOT NULL DEFAULT '' COMMENT 'comma separated, 0 = sunday ... 6 = saturday',
+  `start_date` DATE NULL,
+  `end_date` DATE NULL,
+  `min_lead_days` int(11) NOT NULL DEFAULT 0,
+  `max_lead_days` int(11) NOT NULL DEFAULT 0 COMMENT '0 means unbounded',
+  `adjustment_type` varchar(20) NOT NULL COMMENT 'percentage, fixed or override',
+  `adjustment_value` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `priority` int(11) NOT NULL DEFAULT 0 COMMENT 'lower value is applied first',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `auth` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `email` TEXT NOT NULL,
+  `fullname` TEXT NOT NULL,
+  `password` TEXT NOT NULL,
+  `role` varchar(20) NOT NULL DEFAULT 'customer' COMMENT 'customer, owner or admin',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `order` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `package_id`int(11) NOT NULL,
+  `user_id` int(11) NOT NULL,
+  `date` DATE NOT NULL COMMENT 'booked day in the venue timezone',
+  `status` varchar(20) NOT NULL DEFAULT 'confirmed' COMMENT 'confirmed or cancelled',
+  `package_price` DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'package price snapshot at order time',
+  `addon_price` DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'sum of add-on subtotals',
+  `promo_id` int(11) NOT NULL DEFAULT 0,
+  `promo_code` varchar(50) NOT NULL DEFAULT '',
+  `discount` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `tax` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `total_price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `invoice_number` varchar(50) NOT NULL DEFAULT '',
+  `payment_status` varchar(20) NOT NULL DEFAULT 'unpaid' COMMENT 'unpaid or paid',
+  `paid_at` timestamp NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `order_addon` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `order_id` int(11) NOT NULL,
+  `addon_id` int(11) NOT NULL,
+  `name` TEXT NOT NULL COMMENT 'add-on name snapshot at order time',
+  `unit_price` DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'unit price snapshot at order time',
+  `quantity` int(11) NOT NULL DEFAULT 1,
+  `subtotal` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `promo` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `code` varchar(50) NOT NULL,
+  `description` TEXT NOT NULL,
+  `discount_type` varchar(20) NOT NULL COMMENT 'percentage or fixed',
+  `discount_value` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `max_discount` DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT '0 means no cap',
+  `min_spend` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `usage_limit` int(11) NOT NULL DEFAULT 0 COMMENT '0 means unlimited',
+  `usage_limit_per_user` int(11) NOT NULL DEFAULT 0 COMMENT '0 means unlimited',
+  `used_count` int(11) NOT NULL DEFAULT 0,
+  `starts_at` timestamp NULL,
+  `ends_at` timestamp NULL,
+  `venue_id` int(11) NOT NULL DEFAULT 0 COMMENT '0 means any venue',
+  `city_id` int(11) NOT NULL DEFAULT 0 COMMENT '0 means any city',
+  `package_id` int(11) NOT NULL DEFAULT 0 COMMENT '0 means any package',
+  `is_active` TINYINT(1) NOT NULL DEFAULT 1,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_promo_code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `promo_usage` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `promo_id` int(11) NOT NULL,
+  `user_id` int(11) NOT NULL,
+  `order_id` int(11) NOT NULL,
+  `discount` DECIMAL(15, 2) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `venue_owner` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `venue_id` int(11) NOT NULL,
+  `user_id` int(11) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_venue_owner` (`venue_id`, `user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `venue_view_stat` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `venue_id` int(11) NOT NULL,
+  `date` DATE NOT NULL,
+  `views` int(11) NOT NULL DEFAULT 0 COMMENT 'venue detail views on that day',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_venue_view_stat` (`venue_id`, `date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,67 @@
+ALTER TABLE `venue_view_stat`
+  DROP FOREIGN KEY `fk_venue_view_stat_venue`;
+
+ALTER TABLE `venue_owner`
+  DROP FOREIGN KEY `fk_venue_owner_venue`,
+  DROP FOREIGN KEY `fk_venue_owner_user`;
+ALTER TABLE `venue_owner`
+  DROP KEY `idx_venue_owner_user_id`;
+
+ALTER TABLE `promo_usage`
+  DROP FOREIGN KEY `fk_promo_usage_promo`,
+  DROP FOREIGN KEY `fk_promo_usage_order`,
+  DROP FOREIGN KEY `fk_promo_usage_user`;
+ALTER TABLE `promo_usage`
+  DROP KEY `idx_promo_usage_promo_id_user_id`,
+  DROP KEY `idx_promo_usage_order_id`,
+  DROP KEY `idx_promo_usage_user_id`;
+
+ALTER TABLE `order_addon`
+  DROP FOREIGN KEY `fk_order_addon_order`,
+  DROP FOREIGN KEY `fk_order_addon_addon`;
+ALTER TABLE `order_addon`
+  DROP KEY `idx_order_addon_order_id`,
+  DROP KEY `idx_order_addon_addon_id`;
+
+ALTER TABLE `order`
+  DROP FOREIGN KEY `fk_order_package`,
+  DROP FOREIGN KEY `fk_order_user`;
+ALTER TABLE `order`
+  DROP KEY `idx_order_package_id_date`,
+  DROP KEY `idx_order_date`,
+  DROP KEY `idx_order_user_id`,
+  DROP KEY `idx_order_created_at`;
+
+ALTER TABLE `package_price_rule`
+  DROP FOREIGN KEY `fk_package_price_rule_package`;
+ALTER TABLE `package_price_rule`
+  DROP KEY `idx_package_price_rule_package_id`;
+
+ALTER TABLE `package_addon`
+  DROP FOREIGN KEY `fk_package_addon_package`;
+ALTER TABLE `package_addon`
+  DROP KEY `idx_package_addon_package_id`;
+
+ALTER TABLE `category_package`
+  DROP FOREIGN KEY `fk_category_package_category`;
+ALTER TABLE `category_package`
+  DROP KEY `idx_category_package_category_id`;
+
+ALTER TABLE `venue_category_package`
+  DROP FOREIGN KEY `fk_venue_category_package_venue`;
+ALTER TABLE `venue_category_package`
+  DROP KEY `idx_venue_category_package_venue_id`;
+
+ALTER TABLE `venue_gallery`
+  DROP FOREIGN KEY `fk_venue_gallery_venue`;
+ALTER TABLE `venue_gallery`
+  DROP KEY `idx_venue_gallery_venue_id`;
+
+ALTER TABLE `venue`
+  DROP FOREIGN KEY `fk_venue_city`;
+ALTER TABLE `venue`
+  DROP KEY `idx_venue_city_id`;
+
+ALTER TABLE `auth`
+  DROP KEY `uniq_auth_email`,
+  MODIFY `email` TEXT NOT NULL;
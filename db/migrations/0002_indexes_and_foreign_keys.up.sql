@@ -0,0 +1,63 @@
+-- Emails are compared case-insensitively by the collation, so the unique key
+-- rejects addresses differing only in case and lookups can use the index.
+-- The statement fails when such duplicates already exist, resolve them with:
+--   SELECT LOWER(email), COUNT(*) FROM auth GROUP BY LOWER(email) HAVING COUNT(*) > 1;
+ALTER TABLE `auth`
+  MODIFY `email` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  ADD UNIQUE KEY `uniq_auth_email` (`email`);
+
+ALTER TABLE `venue`
+  ADD KEY `idx_venue_city_id` (`city_id`),
+  ADD CONSTRAINT `fk_venue_city` FOREIGN KEY (`city_id`) REFERENCES `city` (`id`);
+
+ALTER TABLE `venue_gallery`
+  ADD KEY `idx_venue_gallery_venue_id` (`venue_id`),
+  ADD CONSTRAINT `fk_venue_gallery_venue` FOREIGN KEY (`venue_id`) REFERENCES `venue` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `venue_category_package`
+  ADD KEY `idx_venue_category_package_venue_id` (`venue_id`),
+  ADD CONSTRAINT `fk_venue_category_package_venue` FOREIGN KEY (`venue_id`) REFERENCES `venue` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `category_package`
+  ADD KEY `idx_category_package_category_id` (`category_id`),
+  ADD CONSTRAINT `fk_category_package_category` FOREIGN KEY (`category_id`) REFERENCES `venue_category_package` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `package_addon`
+  ADD KEY `idx_package_addon_package_id` (`package_id`),
+  ADD CONSTRAINT `fk_package_addon_package` FOREIGN KEY (`package_id`) REFERENCES `category_package` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `package_price_rule`
+  ADD KEY `idx_package_price_rule_package_id` (`package_id`, `priority`),
+  ADD CONSTRAINT `fk_package_price_rule_package` FOREIGN KEY (`package_id`) REFERENCES `category_package` (`id`) ON DELETE CASCADE;
+
+-- Orders are kept when a package or user is removed, so those deletes are
+-- rejected instead of cascading.
+ALTER TABLE `order`
+  ADD KEY `idx_order_package_id_date` (`package_id`, `date`),
+  ADD KEY `idx_order_date` (`date`),
+  ADD KEY `idx_order_user_id` (`user_id`),
+  ADD KEY `idx_order_created_at` (`created_at`),
+  ADD CONSTRAINT `fk_order_package` FOREIGN KEY (`package_id`) REFERENCES `category_package` (`id`),
+  ADD CONSTRAINT `fk_order_user` FOREIGN KEY (`user_id`) REFERENCES `auth` (`id`);
+
+ALTER TABLE `order_addon`
+  ADD KEY `idx_order_addon_order_id` (`order_id`),
+  ADD KEY `idx_order_addon_addon_id` (`addon_id`),
+  ADD CONSTRAINT `fk_order_addon_order` FOREIGN KEY (`order_id`) REFERENCES `order` (`id`) ON DELETE CASCADE,
+  ADD CONSTRAINT `fk_order_addon_addon` FOREIGN KEY (`addon_id`) REFERENCES `package_addon` (`id`);
+
+ALTER TABLE `promo_usage`
+  ADD KEY `idx_promo_usage_promo_id_user_id` (`promo_id`, `user_id`),
+  ADD KEY `idx_promo_usage_order_id` (`order_id`),
+  ADD KEY `idx_promo_usage_user_id` (`user_id`),
+  ADD CONSTRAINT `fk_promo_usage_promo` FOREIGN KEY (`promo_id`) REFERENCES `promo` (`id`),
+  ADD CONSTRAINT `fk_promo_usage_order` FOREIGN KEY (`order_id`) REFERENCES `order` (`id`) ON DELETE CASCADE,
+  ADD CONSTRAINT `fk_promo_usage_user` FOREIGN KEY (`user_id`) REFERENCES `auth` (`id`);
+
+ALTER TABLE `venue_owner`
+  ADD KEY `idx_venue_owner_user_id` (`user_id`),
+  ADD CONSTRAINT `fk_venue_owner_venue` FOREIGN KEY (`venue_id`) REFERENCES `venue` (`id`) ON DELETE CASCADE,
+  ADD CONSTRAINT `fk_venue_owner_user` FOREIGN KEY (`user_id`) REFERENCES `auth` (`id`) ON DELETE CASCADE;
+
+ALTER TABLE `venue_view_stat`
+  ADD CONSTRAINT `fk_venue_view_stat_venue` FOREIGN KEY (`venue_id`) REFERENCES `venue` (`id`) ON DELETE CASCADE;
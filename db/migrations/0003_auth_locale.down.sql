@@ -0,0 +1,2 @@
+ALTER TABLE `auth`
+  DROP COLUMN `locale`;
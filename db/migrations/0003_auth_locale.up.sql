@@ -0,0 +1,2 @@
+ALTER TABLE `auth`
+  ADD COLUMN `locale` varchar(8) NOT NULL DEFAULT '' COMMENT 'preferred locale of the messages, id or en, empty to follow Accept-Language' AFTER `role`;
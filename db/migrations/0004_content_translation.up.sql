@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `content_translation` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `content_type` varchar(32) NOT NULL COMMENT 'venue, category or package',
+  `content_id` int(11) NOT NULL COMMENT 'id of the venue, category or package',
+  `locale` varchar(8) NOT NULL COMMENT 'locale of the value, the content columns hold the default one',
+  `field` varchar(32) NOT NULL COMMENT 'translated column, e.g. description',
+  `value` TEXT NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
+  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_content_translation` (`content_type`, `content_id`, `locale`, `field`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,4 @@
+ALTER TABLE `venue`
+  DROP KEY `idx_venue_min_price_id`,
+  DROP KEY `idx_venue_star_id`,
+  DROP KEY `idx_venue_capacity_id`;
@@ -0,0 +1,6 @@
+-- The venue list sorts by one of these columns, the id breaking ties, and
+-- pages with a cursor on both.
+ALTER TABLE `venue`
+  ADD KEY `idx_venue_min_price_id` (`min_price`, `id`),
+  ADD KEY `idx_venue_star_id` (`star`, `id`),
+  ADD KEY `idx_venue_capacity_id` (`capacity`, `id`);
@@ -1,105 +1,3 @@
-create database if not exists venue_db;
-
-use venue_db;
-
-CREATE TABLE IF NOT EXISTS `venue` (
-  `id` int(11) NOT NULL AUTO_INCREMENT COMMENT 'venue identifier',
-  `name` TEXT NOT NULL,
-  `min_price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
-  `max_price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
-  `capacity` int(11) NOT NULL DEFAULT 0,
-  `star` DECIMAL(15, 2) NOT NULL DEFAULT 0,
-  `review_count` int(11) NOT NULL DEFAULT 0,
-  `thumbnail_url` TEXT NOT NULL,
-  `city_id` int(11) NOT NULL,
-  `description` TEXT NOT NULL,
-  `website` TEXT NOT NULL,
-  `phone` varchar(13) NOT NULL DEFAULT '',
-  `email` varchar(255) NOT NULL DEFAULT '',
-  `instagram` varchar(255) NOT NULL DEFAULT '',
-  `address` TEXT NOT NULL,
-  `logo` TEXT NOT NULL,
-  `is_favourite` TINYINT(1) NOT NULL DEFAULT 0,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
-CREATE TABLE IF NOT EXISTS `city` (
-  `id` int(11) NOT NULL AUTO_INCREMENT COMMENT 'city identifier',
-  `name` TEXT NOT NULL,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
-CREATE TABLE IF NOT EXISTS `venue_gallery` (
-  `id` int(11) NOT NULL AUTO_INCREMENT,
-  `venue_id` int(11) NOT NULL,
-  `file_url` TEXT NOT NULL,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
-
-CREATE TABLE IF NOT EXISTS `venue_category_package` (
-  `id` int(11) NOT NULL AUTO_INCREMENT,
-  `venue_id` int(11) NOT NULL,
-  `description` TEXT NOT NULL,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
-CREATE TABLE IF NOT EXISTS `category_package` (
-  `id` int(11) NOT NULL AUTO_INCREMENT,
-  `category_id` int(11) NOT NULL,
-  `name` TEXT NOT NULL,
-  `thumbnail_url` TEXT NOT NULL,
-  `description` TEXT NOT NULL,
-  `price` DECIMAL(15, 2) NOT NULL DEFAULT 0,
-  `capacity` int(11) NOT NULL DEFAULT 0,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
-CREATE TABLE IF NOT EXISTS `auth` (
-  `id` int(11) NOT NULL AUTO_INCREMENT,
-  `email` TEXT NOT NULL,
-  `fullname` TEXT NOT NULL,
-  `password` TEXT NOT NULL,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
-CREATE TABLE IF NOT EXISTS `order` (
-  `id` int(11) NOT NULL AUTO_INCREMENT,
-  `package_id`int(11) NOT NULL,
-  `user_id` int(11) NOT NULL,
-  `date` timestamp NOT NULL,
-  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'created date',
-  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who create this entity',
-  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'update date',
-  `updated_by` varchar(255) NOT NULL DEFAULT '' COMMENT 'user who update this entity',
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
-
--- SEEDER
 INSERT INTO city(id,name,created_at,created_by,updated_at,updated_by) VALUES
 (1,'Surabaya',NOW(),'user',NOW(),'user'),
 (2,'Sidoarjo',NOW(),'user',NOW(),'user'),
@@ -118,7 +16,7 @@ INSERT INTO city(id,name,created_at,created_by,updated_at,updated_by) VALUES
 (15,'Banyuwangi',NOW(),'user',NOW(),'user'),
 (16,'Situbondo',NOW(),'user',NOW(),'user');
 
-INSERT INTO venue_db.venue (name,min_price,max_price,capacity,star,review_count,thumbnail_url,city_id,description,website,phone,email,instagram,address,logo,is_favourite,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue (name,min_price,max_price,capacity,star,review_count,thumbnail_url,city_id,description,website,phone,email,instagram,address,logo,is_favourite,created_at,created_by,updated_at,updated_by) VALUES
 	 ('Shangri-La Hotel',1500000.00,200000000.00,500,4.50,100,'https://picsum.photos/700/700',1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web1.com','021382921','shangri-la@mail.com','@shangri-la','Surabaya','https://picsum.photos/200',1,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('Hotel Bumi',2000000.00,500000000.00,500,5.00,80,'https://picsum.photos/700/700',1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web2.com','021382922','dummy1@mail.com','@dummyhotel1','Surabaya','https://picsum.photos/200',1,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('Royal Regantris Cendana Formerly Royal Singosari',2000000.00,500000000.00,500,5.00,80,'https://picsum.photos/700/700',1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web3.com','021382923','dummy2@mail.com','@dummyhotel2','Surabaya','https://picsum.photos/200',1,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
@@ -129,7 +27,7 @@ INSERT INTO venue_db.venue (name,min_price,max_price,capacity,star,review_count,
 	 ('Fairfield by Marriott Surabaya ',2000000.00,500000000.00,500,5.00,80,'https://picsum.photos/700/700',1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web8.com','021382928','dummy7@mail.com','@dummyhotel7','Surabaya','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('Kampi Hotel Tunjungan - Surabaya ',2000000.00,500000000.00,500,5.00,80,'https://picsum.photos/700/700',1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web9.com','021382929','dummy8@mail.com','@dummyhotel8','Surabaya','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('POP! Hotel Diponegoro ',2000000.00,500000000.00,500,5.00,80,'https://picsum.photos/700/700',1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web10.com','0213829210','dummy9@mail.com','@dummyhotel9','Surabaya','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42','');
-INSERT INTO venue_db.venue (name,min_price,max_price,capacity,star,review_count,thumbnail_url,city_id,description,website,phone,email,instagram,address,logo,is_favourite,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue (name,min_price,max_price,capacity,star,review_count,thumbnail_url,city_id,description,website,phone,email,instagram,address,logo,is_favourite,created_at,created_by,updated_at,updated_by) VALUES
 	 ('Aston Sidoarjo City Hotel & Conference Center',2000000.00,500000000.00,300,3.00,10,'https://picsum.photos/700/700',2,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web10.com','0213829210','dummy9@mail.com','@dummyhotel10','Sidoarjo','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('The Sun Hotel Sidoarjo',2000000.00,500000000.00,200,3.00,3,'https://picsum.photos/700/700',2,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web10.com','0213829210','dummy9@mail.com','@dummyhotel11','Sidoarjo','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('favehotel Sidoarjo',2000000.00,500000000.00,100,1.00,80,'https://picsum.photos/700/700',2,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web10.com','0213829210','dummy9@mail.com','@dummyhotel12','Sidoarjo','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
@@ -144,7 +42,7 @@ Hotel
 	 ('Sofie Syariah',1000000.00,3000000.00,100,2.00,80,'https://picsum.photos/700/700',2,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web10.com','0213829210','dummy9@mail.com','@dummyhotel17','Sidoarjo','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42',''),
 	 ('Front One Inn Sidoarjo',200000.00,10000000.00,100,2.00,9,'https://picsum.photos/700/700',2,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.','web10.com','0213829210','dummy9@mail.com','@dummyhotel18','Sidoarjo','https://picsum.photos/200',0,'2023-02-19 07:44:42','','2023-02-19 07:44:42','');
 
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -155,7 +53,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -166,7 +64,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (1,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -177,7 +75,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -188,7 +86,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -199,7 +97,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (2,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -210,7 +108,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -221,7 +119,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (3,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -232,7 +130,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (4,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -243,7 +141,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (5,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (5,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (5,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (5,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (5,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (5,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -254,7 +152,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (6,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (6,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (6,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (6,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (6,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (7,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -265,7 +163,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (7,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (7,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (7,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (7,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (8,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (8,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -276,7 +174,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (8,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (8,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -287,7 +185,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (9,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (10,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (10,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (10,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -298,7 +196,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (11,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (11,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (11,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (11,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (11,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (11,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -309,7 +207,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -320,7 +218,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (12,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (13,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (13,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (13,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (13,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -331,7 +229,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (13,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (13,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (14,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (14,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (14,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (14,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -342,7 +240,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -353,7 +251,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (15,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -364,7 +262,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (16,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -375,7 +273,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (17,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (17,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (17,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (17,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (17,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (17,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -386,7 +284,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -397,7 +295,7 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (18,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
@@ -408,13 +306,13 @@ INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,upda
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
-INSERT INTO venue_db.venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_gallery (venue_id,file_url,created_at,created_by,updated_at,updated_by) VALUES
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18',''),
 	 (19,'https://picsum.photos/700/700','2023-02-19 13:37:18','','2023-02-19 13:37:18','');
 
-INSERT INTO venue_db.venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
 	 (1,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59',''),
@@ -445,7 +343,7 @@ In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursu
 	 (3,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59','');
-INSERT INTO venue_db.venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
 	 (3,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59',''),
@@ -476,7 +374,7 @@ In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursu
 	 (4,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59','');
-INSERT INTO venue_db.venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
 	 (4,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59',''),
@@ -507,7 +405,7 @@ In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursu
 	 (7,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59','');
-INSERT INTO venue_db.venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
 	 (8,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59',''),
@@ -538,7 +436,7 @@ In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursu
 	 (13,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59','');
-INSERT INTO venue_db.venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO venue_category_package (venue_id,description,created_at,created_by,updated_at,updated_by) VALUES
 	 (14,'Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Leo in vitae turpis massa sed. Arcu dui vivamus arcu felis bibendum ut. Interdum posuere lorem ipsum dolor sit amet consectetur adipiscing. Adipiscing elit pellentesque habitant morbi tristique senectus et netus et. Et odio pellentesque diam volutpat commodo. Urna id volutpat lacus laoreet non curabitur gravida arcu. Id neque aliquam vestibulum morbi blandit cursus risus. Eu feugiat pretium nibh ipsum. Amet luctus venenatis lectus magna fringilla urna porttitor rhoncus dolor. Ut tortor pretium viverra suspendisse. Sollicitudin aliquam ultrices sagittis orci a. Fringilla urna porttitor rhoncus dolor purus non enim. Posuere ac ut consequat semper viverra. Metus aliquam eleifend mi in nulla posuere sollicitudin aliquam. At lectus urna duis convallis convallis tellus id interdum velit. Fermentum posuere urna nec tincidunt. Hendrerit dolor magna eget est lorem ipsum dolor sit amet. Lorem donec massa sapien faucibus et molestie ac feugiat sed. Dolor sit amet consectetur adipiscing elit.
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59',''),
@@ -558,7 +456,7 @@ In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursu
 
 In vitae turpis massa sed elementum. Lorem dolor sed viverra ipsum. Turpis cursus in hac habitasse platea. Amet purus gravida quis blandit turpis cursus in. Nisl condimentum id venenatis a condimentum vitae. Sed risus pretium quam vulputate dignissim suspendisse in. Erat velit scelerisque in dictum non consectetur. Convallis convallis tellus id interdum. Eu volutpat odio facilisis mauris sit amet massa vitae. Et pharetra pharetra massa massa ultricies mi quis hendrerit dolor. Scelerisque eu ultrices vitae auctor eu augue ut lectus arcu.','2023-02-19 07:44:59','','2023-02-19 07:44:59','');
 
-INSERT INTO venue_db.category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
 	 (1,'Package 1','https://picsum.photos/700/700','Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Lobortis elementum nibh tellus molestie nunc non blandit. Lorem ipsum dolor sit amet consectetur adipiscing. Aenean vel elit scelerisque mauris pellentesque pulvinar pellentesque. Metus vulputate eu scelerisque felis imperdiet proin fermentum. Aliquam nulla facilisi cras fermentum. Viverra aliquet eget sit amet tellus cras adipiscing. Lorem sed risus ultricies tristique nulla. Elementum nisi quis eleifend quam adipiscing vitae proin sagittis. Nunc sed velit dignissim sodales ut eu. Sit amet consectetur adipiscing elit duis tristique sollicitudin. Tellus at urna condimentum mattis. Mauris sit amet massa vitae tortor condimentum.
 
 Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin nibh nisl condimentum id venenatis. Duis at consectetur lorem donec massa sapien faucibus et. Fames ac turpis egestas sed tempus urna et. Nulla facilisi etiam dignissim diam. Ipsum nunc aliquet bibendum enim. Pulvinar pellentesque habitant morbi tristique senectus et. Mauris pharetra et ultrices neque. Aliquam etiam erat velit scelerisque. Vitae congue mauris rhoncus aenean vel elit scelerisque mauris pellentesque. Iaculis urna id volutpat lacus laoreet non curabitur. Nulla facilisi cras fermentum odio eu feugiat. Eget nullam non nisi est sit amet facilisis magna.
@@ -629,7 +527,7 @@ Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin n
 Mi bibendum neque egestas congue quisque egestas diam. Semper quis lectus nulla at. Blandit turpis cursus in hac habitasse platea dictumst quisque sagittis. Sed egestas egestas fringilla phasellus faucibus scelerisque eleifend donec pretium. Neque laoreet suspendisse interdum consectetur libero id. Sed risus ultricies tristique nulla aliquet enim tortor at. Mauris in aliquam sem fringilla ut. Aenean euismod elementum nisi quis. Sed enim ut sem viverra aliquet. Quis imperdiet massa tincidunt nunc pulvinar sapien et ligula ullamcorper. Pharetra diam sit amet nisl suscipit adipiscing bibendum est. Bibendum est ultricies integer quis auctor elit sed vulputate mi. Commodo ullamcorper a lacus vestibulum sed arcu non odio euismod. Dolor morbi non arcu risus quis. Ut etiam sit amet nisl purus in mollis nunc sed. Id aliquet lectus proin nibh nisl condimentum.
 
 Amet porttitor eget dolor morbi non. Iaculis urna id volutpat lacus laoreet non curabitur gravida. Pulvinar sapien et ligula ullamcorper malesuada proin libero nunc consequat. Purus sit amet volutpat consequat mauris nunc. Nisi porta lorem mollis aliquam ut porttitor leo a. Mauris pellentesque pulvinar pellentesque habitant morbi tristique senectus et netus. Massa ultricies mi quis hendrerit dolor magna. Venenatis a condimentum vitae sapien pellentesque habitant morbi. Neque ornare aenean euismod elementum nisi quis eleifend quam. Diam maecenas ultricies mi eget mauris. Arcu odio ut sem nulla pharetra diam sit amet nisl. Nisl nisi scelerisque eu ultrices vitae auctor. Condimentum lacinia quis vel eros. Iaculis eu non diam phasellus vestibulum lorem sed risus. Aliquam vestibulum morbi blandit cursus risus at ultrices. Interdum varius sit amet mattis.',1000000.00,100,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
-INSERT INTO venue_db.category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
 	 (11,'Package 1','https://picsum.photos/700/700','Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Lobortis elementum nibh tellus molestie nunc non blandit. Lorem ipsum dolor sit amet consectetur adipiscing. Aenean vel elit scelerisque mauris pellentesque pulvinar pellentesque. Metus vulputate eu scelerisque felis imperdiet proin fermentum. Aliquam nulla facilisi cras fermentum. Viverra aliquet eget sit amet tellus cras adipiscing. Lorem sed risus ultricies tristique nulla. Elementum nisi quis eleifend quam adipiscing vitae proin sagittis. Nunc sed velit dignissim sodales ut eu. Sit amet consectetur adipiscing elit duis tristique sollicitudin. Tellus at urna condimentum mattis. Mauris sit amet massa vitae tortor condimentum.
 
 Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin nibh nisl condimentum id venenatis. Duis at consectetur lorem donec massa sapien faucibus et. Fames ac turpis egestas sed tempus urna et. Nulla facilisi etiam dignissim diam. Ipsum nunc aliquet bibendum enim. Pulvinar pellentesque habitant morbi tristique senectus et. Mauris pharetra et ultrices neque. Aliquam etiam erat velit scelerisque. Vitae congue mauris rhoncus aenean vel elit scelerisque mauris pellentesque. Iaculis urna id volutpat lacus laoreet non curabitur. Nulla facilisi cras fermentum odio eu feugiat. Eget nullam non nisi est sit amet facilisis magna.
@@ -700,7 +598,7 @@ Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin n
 Mi bibendum neque egestas congue quisque egestas diam. Semper quis lectus nulla at. Blandit turpis cursus in hac habitasse platea dictumst quisque sagittis. Sed egestas egestas fringilla phasellus faucibus scelerisque eleifend donec pretium. Neque laoreet suspendisse interdum consectetur libero id. Sed risus ultricies tristique nulla aliquet enim tortor at. Mauris in aliquam sem fringilla ut. Aenean euismod elementum nisi quis. Sed enim ut sem viverra aliquet. Quis imperdiet massa tincidunt nunc pulvinar sapien et ligula ullamcorper. Pharetra diam sit amet nisl suscipit adipiscing bibendum est. Bibendum est ultricies integer quis auctor elit sed vulputate mi. Commodo ullamcorper a lacus vestibulum sed arcu non odio euismod. Dolor morbi non arcu risus quis. Ut etiam sit amet nisl purus in mollis nunc sed. Id aliquet lectus proin nibh nisl condimentum.
 
 Amet porttitor eget dolor morbi non. Iaculis urna id volutpat lacus laoreet non curabitur gravida. Pulvinar sapien et ligula ullamcorper malesuada proin libero nunc consequat. Purus sit amet volutpat consequat mauris nunc. Nisi porta lorem mollis aliquam ut porttitor leo a. Mauris pellentesque pulvinar pellentesque habitant morbi tristique senectus et netus. Massa ultricies mi quis hendrerit dolor magna. Venenatis a condimentum vitae sapien pellentesque habitant morbi. Neque ornare aenean euismod elementum nisi quis eleifend quam. Diam maecenas ultricies mi eget mauris. Arcu odio ut sem nulla pharetra diam sit amet nisl. Nisl nisi scelerisque eu ultrices vitae auctor. Condimentum lacinia quis vel eros. Iaculis eu non diam phasellus vestibulum lorem sed risus. Aliquam vestibulum morbi blandit cursus risus at ultrices. Interdum varius sit amet mattis.',1000000.00,100,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
-INSERT INTO venue_db.category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
 	 (21,'Package 1','https://picsum.photos/700/700','Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Lobortis elementum nibh tellus molestie nunc non blandit. Lorem ipsum dolor sit amet consectetur adipiscing. Aenean vel elit scelerisque mauris pellentesque pulvinar pellentesque. Metus vulputate eu scelerisque felis imperdiet proin fermentum. Aliquam nulla facilisi cras fermentum. Viverra aliquet eget sit amet tellus cras adipiscing. Lorem sed risus ultricies tristique nulla. Elementum nisi quis eleifend quam adipiscing vitae proin sagittis. Nunc sed velit dignissim sodales ut eu. Sit amet consectetur adipiscing elit duis tristique sollicitudin. Tellus at urna condimentum mattis. Mauris sit amet massa vitae tortor condimentum.
 
 Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin nibh nisl condimentum id venenatis. Duis at consectetur lorem donec massa sapien faucibus et. Fames ac turpis egestas sed tempus urna et. Nulla facilisi etiam dignissim diam. Ipsum nunc aliquet bibendum enim. Pulvinar pellentesque habitant morbi tristique senectus et. Mauris pharetra et ultrices neque. Aliquam etiam erat velit scelerisque. Vitae congue mauris rhoncus aenean vel elit scelerisque mauris pellentesque. Iaculis urna id volutpat lacus laoreet non curabitur. Nulla facilisi cras fermentum odio eu feugiat. Eget nullam non nisi est sit amet facilisis magna.
@@ -771,7 +669,7 @@ Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin n
 Mi bibendum neque egestas congue quisque egestas diam. Semper quis lectus nulla at. Blandit turpis cursus in hac habitasse platea dictumst quisque sagittis. Sed egestas egestas fringilla phasellus faucibus scelerisque eleifend donec pretium. Neque laoreet suspendisse interdum consectetur libero id. Sed risus ultricies tristique nulla aliquet enim tortor at. Mauris in aliquam sem fringilla ut. Aenean euismod elementum nisi quis. Sed enim ut sem viverra aliquet. Quis imperdiet massa tincidunt nunc pulvinar sapien et ligula ullamcorper. Pharetra diam sit amet nisl suscipit adipiscing bibendum est. Bibendum est ultricies integer quis auctor elit sed vulputate mi. Commodo ullamcorper a lacus vestibulum sed arcu non odio euismod. Dolor morbi non arcu risus quis. Ut etiam sit amet nisl purus in mollis nunc sed. Id aliquet lectus proin nibh nisl condimentum.
 
 Amet porttitor eget dolor morbi non. Iaculis urna id volutpat lacus laoreet non curabitur gravida. Pulvinar sapien et ligula ullamcorper malesuada proin libero nunc consequat. Purus sit amet volutpat consequat mauris nunc. Nisi porta lorem mollis aliquam ut porttitor leo a. Mauris pellentesque pulvinar pellentesque habitant morbi tristique senectus et netus. Massa ultricies mi quis hendrerit dolor magna. Venenatis a condimentum vitae sapien pellentesque habitant morbi. Neque ornare aenean euismod elementum nisi quis eleifend quam. Diam maecenas ultricies mi eget mauris. Arcu odio ut sem nulla pharetra diam sit amet nisl. Nisl nisi scelerisque eu ultrices vitae auctor. Condimentum lacinia quis vel eros. Iaculis eu non diam phasellus vestibulum lorem sed risus. Aliquam vestibulum morbi blandit cursus risus at ultrices. Interdum varius sit amet mattis.',1000000.00,100,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
-INSERT INTO venue_db.category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
 	 (31,'Package 1','https://picsum.photos/700/700','Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Lobortis elementum nibh tellus molestie nunc non blandit. Lorem ipsum dolor sit amet consectetur adipiscing. Aenean vel elit scelerisque mauris pellentesque pulvinar pellentesque. Metus vulputate eu scelerisque felis imperdiet proin fermentum. Aliquam nulla facilisi cras fermentum. Viverra aliquet eget sit amet tellus cras adipiscing. Lorem sed risus ultricies tristique nulla. Elementum nisi quis eleifend quam adipiscing vitae proin sagittis. Nunc sed velit dignissim sodales ut eu. Sit amet consectetur adipiscing elit duis tristique sollicitudin. Tellus at urna condimentum mattis. Mauris sit amet massa vitae tortor condimentum.
 
 Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin nibh nisl condimentum id venenatis. Duis at consectetur lorem donec massa sapien faucibus et. Fames ac turpis egestas sed tempus urna et. Nulla facilisi etiam dignissim diam. Ipsum nunc aliquet bibendum enim. Pulvinar pellentesque habitant morbi tristique senectus et. Mauris pharetra et ultrices neque. Aliquam etiam erat velit scelerisque. Vitae congue mauris rhoncus aenean vel elit scelerisque mauris pellentesque. Iaculis urna id volutpat lacus laoreet non curabitur. Nulla facilisi cras fermentum odio eu feugiat. Eget nullam non nisi est sit amet facilisis magna.
@@ -842,7 +740,7 @@ Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin n
 Mi bibendum neque egestas congue quisque egestas diam. Semper quis lectus nulla at. Blandit turpis cursus in hac habitasse platea dictumst quisque sagittis. Sed egestas egestas fringilla phasellus faucibus scelerisque eleifend donec pretium. Neque laoreet suspendisse interdum consectetur libero id. Sed risus ultricies tristique nulla aliquet enim tortor at. Mauris in aliquam sem fringilla ut. Aenean euismod elementum nisi quis. Sed enim ut sem viverra aliquet. Quis imperdiet massa tincidunt nunc pulvinar sapien et ligula ullamcorper. Pharetra diam sit amet nisl suscipit adipiscing bibendum est. Bibendum est ultricies integer quis auctor elit sed vulputate mi. Commodo ullamcorper a lacus vestibulum sed arcu non odio euismod. Dolor morbi non arcu risus quis. Ut etiam sit amet nisl purus in mollis nunc sed. Id aliquet lectus proin nibh nisl condimentum.
 
 Amet porttitor eget dolor morbi non. Iaculis urna id volutpat lacus laoreet non curabitur gravida. Pulvinar sapien et ligula ullamcorper malesuada proin libero nunc consequat. Purus sit amet volutpat consequat mauris nunc. Nisi porta lorem mollis aliquam ut porttitor leo a. Mauris pellentesque pulvinar pellentesque habitant morbi tristique senectus et netus. Massa ultricies mi quis hendrerit dolor magna. Venenatis a condimentum vitae sapien pellentesque habitant morbi. Neque ornare aenean euismod elementum nisi quis eleifend quam. Diam maecenas ultricies mi eget mauris. Arcu odio ut sem nulla pharetra diam sit amet nisl. Nisl nisi scelerisque eu ultrices vitae auctor. Condimentum lacinia quis vel eros. Iaculis eu non diam phasellus vestibulum lorem sed risus. Aliquam vestibulum morbi blandit cursus risus at ultrices. Interdum varius sit amet mattis.',1000000.00,100,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
-INSERT INTO venue_db.category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
+INSERT INTO category_package (category_id,name,thumbnail_url,description,price,capacity,created_at,created_by,updated_at,updated_by) VALUES
 	 (41,'Package 1','https://picsum.photos/700/700','Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Lobortis elementum nibh tellus molestie nunc non blandit. Lorem ipsum dolor sit amet consectetur adipiscing. Aenean vel elit scelerisque mauris pellentesque pulvinar pellentesque. Metus vulputate eu scelerisque felis imperdiet proin fermentum. Aliquam nulla facilisi cras fermentum. Viverra aliquet eget sit amet tellus cras adipiscing. Lorem sed risus ultricies tristique nulla. Elementum nisi quis eleifend quam adipiscing vitae proin sagittis. Nunc sed velit dignissim sodales ut eu. Sit amet consectetur adipiscing elit duis tristique sollicitudin. Tellus at urna condimentum mattis. Mauris sit amet massa vitae tortor condimentum.
 
 Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin nibh nisl condimentum id venenatis. Duis at consectetur lorem donec massa sapien faucibus et. Fames ac turpis egestas sed tempus urna et. Nulla facilisi etiam dignissim diam. Ipsum nunc aliquet bibendum enim. Pulvinar pellentesque habitant morbi tristique senectus et. Mauris pharetra et ultrices neque. Aliquam etiam erat velit scelerisque. Vitae congue mauris rhoncus aenean vel elit scelerisque mauris pellentesque. Iaculis urna id volutpat lacus laoreet non curabitur. Nulla facilisi cras fermentum odio eu feugiat. Eget nullam non nisi est sit amet facilisis magna.
@@ -878,3 +776,18 @@ Mauris a diam maecenas sed enim ut. Ut venenatis tellus in metus. Lectus proin n
 Mi bibendum neque egestas congue quisque egestas diam. Semper quis lectus nulla at. Blandit turpis cursus in hac habitasse platea dictumst quisque sagittis. Sed egestas egestas fringilla phasellus faucibus scelerisque eleifend donec pretium. Neque laoreet suspendisse interdum consectetur libero id. Sed risus ultricies tristique nulla aliquet enim tortor at. Mauris in aliquam sem fringilla ut. Aenean euismod elementum nisi quis. Sed enim ut sem viverra aliquet. Quis imperdiet massa tincidunt nunc pulvinar sapien et ligula ullamcorper. Pharetra diam sit amet nisl suscipit adipiscing bibendum est. Bibendum est ultricies integer quis auctor elit sed vulputate mi. Commodo ullamcorper a lacus vestibulum sed arcu non odio euismod. Dolor morbi non arcu risus quis. Ut etiam sit amet nisl purus in mollis nunc sed. Id aliquet lectus proin nibh nisl condimentum.
 
 Amet porttitor eget dolor morbi non. Iaculis urna id volutpat lacus laoreet non curabitur gravida. Pulvinar sapien et ligula ullamcorper malesuada proin libero nunc consequat. Purus sit amet volutpat consequat mauris nunc. Nisi porta lorem mollis aliquam ut porttitor leo a. Mauris pellentesque pulvinar pellentesque habitant morbi tristique senectus et netus. Massa ultricies mi quis hendrerit dolor magna. Venenatis a condimentum vitae sapien pellentesque habitant morbi. Neque ornare aenean euismod elementum nisi quis eleifend quam. Diam maecenas ultricies mi eget mauris. Arcu odio ut sem nulla pharetra diam sit amet nisl. Nisl nisi scelerisque eu ultrices vitae auctor. Condimentum lacinia quis vel eros. Iaculis eu non diam phasellus vestibulum lorem sed risus. Aliquam vestibulum morbi blandit cursus risus at ultrices. Interdum varius sit amet mattis.',1000000.00,100,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
+
+INSERT INTO package_addon (package_id,name,description,unit,unit_price,min_quantity,max_quantity,created_at,created_by,updated_at,updated_by) VALUES
+	 (1,'Tambahan Catering','Tambahan porsi catering prasmanan','porsi',75000.00,10,500,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (1,'Dekorasi Bunga','Dekorasi bunga segar untuk pelaminan dan meja tamu','paket',5000000.00,1,1,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (1,'Fotografer','Fotografer profesional beserta hasil edit','orang',2500000.00,1,3,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (1,'Overtime','Tambahan durasi penggunaan venue','jam',1500000.00,1,4,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (2,'Tambahan Catering','Tambahan porsi catering prasmanan','porsi',75000.00,10,500,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (2,'Overtime','Tambahan durasi penggunaan venue','jam',1500000.00,1,4,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
+
+INSERT INTO package_price_rule (package_id,name,rule_type,days_of_week,start_date,end_date,min_lead_days,max_lead_days,adjustment_type,adjustment_value,priority,created_at,created_by,updated_at,updated_by) VALUES
+	 (1,'Akhir pekan','day_of_week','0,6',NULL,NULL,0,0,'percentage',20.00,10,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (1,'Musim pernikahan','date_range','',DATE('2023-06-01'),DATE('2023-08-31'),0,0,'percentage',15.00,20,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (1,'Malam tahun baru','specific_date','',DATE('2023-12-31'),DATE('2023-12-31'),0,0,'override',250000000.00,30,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (1,'Early bird','lead_time','',NULL,NULL,180,0,'percentage',-10.00,40,'2023-02-19 14:08:40','','2023-02-19 14:08:40',''),
+	 (2,'Akhir pekan','day_of_week','0,6',NULL,NULL,0,0,'fixed',5000000.00,10,'2023-02-19 14:08:40','','2023-02-19 14:08:40','');
@@ -0,0 +1,48 @@
+-- Brings a database created by the former db/init.sql to the schema of
+-- migration 0001, run it once before `venue-api migrate up`. 0001 then only
+-- creates the tables init.sql didn't have.
+
+ALTER TABLE `venue`
+  ADD COLUMN `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source' AFTER `id`,
+  ADD COLUMN `timezone` varchar(64) NOT NULL DEFAULT 'Asia/Jakarta' COMMENT 'IANA timezone, booking dates are days in this zone' AFTER `is_favourite`,
+  ADD COLUMN `calendar_token` varchar(64) NOT NULL DEFAULT '' COMMENT 'secret token of the venue calendar feed' AFTER `timezone`,
+  ADD UNIQUE KEY `uniq_venue_external_id` (`external_id`);
+
+ALTER TABLE `venue_gallery`
+  ADD COLUMN `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source' AFTER `id`,
+  ADD UNIQUE KEY `uniq_venue_gallery_external_id` (`external_id`);
+
+ALTER TABLE `venue_category_package`
+  ADD COLUMN `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source' AFTER `id`,
+  ADD UNIQUE KEY `uniq_venue_category_package_external_id` (`external_id`);
+
+ALTER TABLE `category_package`
+  ADD COLUMN `external_id` varchar(64) NULL COMMENT 'key of the record in the bulk import source' AFTER `id`,
+  ADD UNIQUE KEY `uniq_category_package_external_id` (`external_id`);
+
+ALTER TABLE `auth`
+  ADD COLUMN `role` varchar(20) NOT NULL DEFAULT 'customer' COMMENT 'customer, owner or admin' AFTER `password`;
+
+-- init.sql stored the booked day as a timestamp, it becomes the day in the
+-- venue timezone, Asia/Jakarta for every existing venue.
+SET time_zone = '+07:00';
+ALTER TABLE `order`
+  MODIFY `date` DATE NOT NULL COMMENT 'booked day in the venue timezone',
+  ADD COLUMN `status` varchar(20) NOT NULL DEFAULT 'confirmed' COMMENT 'confirmed or cancelled' AFTER `date`,
+  ADD COLUMN `package_price` DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'package price snapshot at order time' AFTER `status`,
+  ADD COLUMN `addon_price` DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT 'sum of add-on subtotals' AFTER `package_price`,
+  ADD COLUMN `promo_id` int(11) NOT NULL DEFAULT 0 AFTER `addon_price`,
+  ADD COLUMN `promo_code` varchar(50) NOT NULL DEFAULT '' AFTER `promo_id`,
+  ADD COLUMN `discount` DECIMAL(15, 2) NOT NULL DEFAULT 0 AFTER `promo_code`,
+  ADD COLUMN `tax` DECIMAL(15, 2) NOT NULL DEFAULT 0 AFTER `discount`,
+  ADD COLUMN `total_price` DECIMAL(15, 2) NOT NULL DEFAULT 0 AFTER `tax`,
+  ADD COLUMN `invoice_number` varchar(50) NOT NULL DEFAULT '' AFTER `total_price`,
+  ADD COLUMN `payment_status` varchar(20) NOT NULL DEFAULT 'unpaid' COMMENT 'unpaid or paid' AFTER `invoice_number`,
+  ADD COLUMN `paid_at` timestamp NULL AFTER `payment_status`;
+SET time_zone = '+00:00';
+
+-- Orders taken before the price snapshot cost the current package price.
+UPDATE `order` o
+  JOIN `category_package` p ON p.id = o.package_id
+SET o.package_price = p.price,
+  o.total_price = p.price;
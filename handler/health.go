@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	"github.com/faruqfadhil/venue-api/pkg/health"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPHealth struct {
+	Status   string           `json:"status"`
+	Draining bool             `json:"draining,omitempty"`
+	Checks   []*health.Result `json:"checks,omitempty"`
+}
+
+// Liveness only tells the process is able to serve, a failing dependency must
+// not get it restarted.
+func (h *HTTPHandler) Liveness(c *gin.Context) {
+	api.ResponseSuccess(c, HTTPHealth{
+		Status: health.StatusUp,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+// Readiness tells whether the app can take traffic, it answers 503 while a
+// dependency is down or the app is draining before shutdown.
+func (h *HTTPHandler) Readiness(c *gin.Context) {
+	ready, results := h.health.Ready(c.Request.Context())
+	out := HTTPHealth{
+		Status:   health.StatusUp,
+		Draining: h.health.Draining(),
+		Checks:   results,
+	}
+	if ready {
+		api.ResponseSuccess(c, out, &api.ResponseMeta{
+			Status: "success",
+			Code:   http.StatusOK,
+		})
+		return
+	}
+	out.Status = health.StatusDown
+	ctx := c.Request.Context()
+	for _, res := range results {
+		if res.Status != health.StatusUp {
+			logger.FromContext(ctx).WarnContext(ctx, "readiness check failed",
+				slog.String("check", res.Name),
+				slog.Duration("latency", res.Latency),
+				slog.String("error", res.Error),
+			)
+		}
+	}
+	c.JSON(http.StatusServiceUnavailable, api.Response{
+		Data: out,
+		Meta: &api.ResponseMeta{
+			Status:    "error",
+			Code:      http.StatusServiceUnavailable,
+			Message:   i18n.T(i18n.FromContext(c.Request.Context()), entity.ErrCodeNotReady),
+			RequestID: api.RequestID(c),
+			ErrorCode: entity.ErrCodeNotReady,
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportSize = 20 << 20
+
+type HTTPImportResult struct {
+	Result *entity.ImportResult `json:"result"`
+}
+
+// HTTPImportQuery is the query string of ImportVenues.
+type HTTPImportQuery struct {
+	Format string `form:"format"`
+	DryRun bool   `form:"dryRun"`
+}
+
+// ImportVenues accepts the import either as a multipart upload in the file
+// field or as the raw request body. The format is taken from the format query
+// parameter, falling back to the file extension and then the content type.
+func (h *HTTPHandler) ImportVenues(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	var query HTTPImportQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	param := &entity.ImportParam{
+		Format:     strings.ToLower(query.Format),
+		DryRun:     query.DryRun,
+		ImportedBy: user.Email,
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			api.ResponseFailed(c, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportFileRequired, err))
+			return
+		}
+		f, err := fh.Open()
+		if err != nil {
+			api.ResponseFailed(c, errutil.NewWithKey(errutil.ErrGeneralBadRequest, entity.ErrCodeImportInvalid, entity.MsgImportFileUnreadable, err))
+			return
+		}
+		defer f.Close()
+		body = f
+		if param.Format == "" {
+			param.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fh.Filename)), ".")
+		}
+	}
+	if param.Format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			param.Format = entity.ImportFormatCSV
+		case "application/json":
+			param.Format = entity.ImportFormatJSON
+		}
+	}
+
+	result, err := h.usecase.ImportVenues(c.Request.Context(), body, param)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, api.Response{
+			Data: HTTPImportResult{
+				Result: result,
+			},
+			Meta: &api.ResponseMeta{
+				Status:    "error",
+				Code:      http.StatusUnprocessableEntity,
+				Message:   i18n.T(i18n.FromContext(c.Request.Context()), entity.ErrCodeImportInvalid, len(result.Errors)),
+				RequestID: api.RequestID(c),
+				ErrorCode: entity.ErrCodeImportInvalid,
+			},
+		})
+		return
+	}
+	api.ResponseSuccess(c, HTTPImportResult{
+		Result: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
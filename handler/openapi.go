@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	"github.com/faruqfadhil/venue-api/pkg/openapi"
+	"github.com/faruqfadhil/venue-api/pkg/xlsx"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tagAccount = "account"
+	tagVenue   = "venue"
+	tagOrder   = "order"
+	tagOwner   = "owner"
+	tagAdmin   = "admin"
+	tagOps     = "operations"
+)
+
+var (
+	ownerRoles = []string{entity.RoleOwner, entity.RoleAdmin}
+	adminRoles = []string{entity.RoleAdmin}
+
+	contentTypes = []string{entity.ContentVenue, entity.ContentCategory, entity.ContentPackage}
+)
+
+// routes documents every route registered in routes.go. TestRoutesDocumented
+// fails when a route is missing here, see openapi.Document.Check.
+var routes = []openapi.Route{
+	{Method: http.MethodGet, Path: "/healthz", Tag: tagOps, Summary: "Liveness probe", Data: HTTPHealth{}},
+	{Method: http.MethodGet, Path: "/readyz", Tag: tagOps, Summary: "Readiness probe", Data: HTTPHealth{}, Errors: []int{http.StatusServiceUnavailable}},
+	{Method: http.MethodGet, Path: "/openapi.json", Tag: tagOps, Summary: "This document", Produces: []string{"application/json"}},
+	{Method: http.MethodGet, Path: "/docs", Tag: tagOps, Summary: "Swagger UI of this document", Produces: []string{"text/html"}},
+
+	{Method: http.MethodGet, Path: "/v1/city", Tag: tagVenue, Summary: "List the cities", Data: []*entity.City{}, Conditional: true},
+	{Method: http.MethodPost, Path: "/v1/register", Tag: tagAccount, Summary: "Register a customer", Body: HTTPRegister{}, Errors: []int{http.StatusConflict}},
+	{Method: http.MethodPost, Path: "/v1/login", Tag: tagAccount, Summary: "Log in", Body: HTTPLogin{}, Data: HTTPLoginResp{}, Errors: []int{http.StatusUnauthorized}},
+	{Method: http.MethodGet, Path: "/v1/venue", Tag: tagVenue, Summary: "List the venues", Description: "With a date, only the venues having a package available that day, priced for it, and sort can't be price. meta.nextCursor continues the list in the same sort, fields trims each venue to the fields listed and skipCount leaves the totals out.", Query: HTTPVenuesQuery{}, Data: HTTPVenues{}},
+	{Method: http.MethodGet, Path: "/v1/nearby", Tag: tagVenue, Summary: "Count the venues per city", Data: HTTPGetNearby{}},
+	{Method: http.MethodGet, Path: "/v1/venue/:id", Tag: tagVenue, Summary: "Get a venue with its packages", Data: HTTPGetVenueDetail{}, Conditional: true, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/v1/venue/:id/calendar.ics", Tag: tagVenue, Summary: "Calendar feed of the bookings of a venue", Query: HTTPVenueCalendarQuery{}, Produces: []string{"text/calendar"}, Errors: []int{http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/v1/venue/package/:id", Tag: tagVenue, Summary: "Get a package with its addons", Data: HTTPGetPackageDetail{}, Conditional: true, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/v1/venue/package/:id/price", Tag: tagVenue, Summary: "Price a package on a date", Query: HTTPPackagePriceQuery{}, Data: HTTPGetPackagePrice{}, Errors: []int{http.StatusNotFound}},
+
+	{Method: http.MethodPost, Path: "/v1/venue/package/order", Tag: tagOrder, Summary: "Book a package", Auth: true, Body: HTTPOrder{}, Data: HTTPOrderResp{}, Errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+	{Method: http.MethodPost, Path: "/v1/promo/validate", Tag: tagOrder, Summary: "Price an order with a promo code", Auth: true, Body: HTTPOrder{}, Data: HTTPValidatePromoResp{}, Errors: []int{http.StatusUnprocessableEntity}},
+	{Method: http.MethodGet, Path: "/v1/orders/:id/invoice", Tag: tagOrder, Summary: "Invoice of an order", Auth: true, Produces: []string{"application/pdf"}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/v1/orders/:id/calendar.ics", Tag: tagOrder, Summary: "Calendar event of an order", Auth: true, Produces: []string{"text/calendar"}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/v1/account/locale", Tag: tagAccount, Summary: "Save the preferred locale", Description: "Answers a new token carrying the preference.", Auth: true, Body: HTTPLocale{}, Data: HTTPLoginResp{}},
+
+	{Method: http.MethodGet, Path: "/v1/owner/venues", Tag: tagOwner, Summary: "List the venues of the owner", Auth: true, Roles: ownerRoles, Data: HTTPVenues{}},
+	{Method: http.MethodGet, Path: "/v1/owner/orders", Tag: tagOwner, Summary: "List the orders of the venues of the owner", Auth: true, Roles: ownerRoles, Query: HTTPOwnerOrdersQuery{}, Data: HTTPOwnerOrders{}},
+	{Method: http.MethodGet, Path: "/v1/owner/venue/:id/calendar", Tag: tagOwner, Summary: "Occupancy of a venue over a month", Auth: true, Roles: ownerRoles, Query: HTTPVenueOccupancyQuery{}, Data: HTTPVenueOccupancy{}, Errors: []int{http.StatusNotFound}},
+	{Method: http.MethodGet, Path: "/v1/owner/stats", Tag: tagOwner, Summary: "Booking statistics of the venues of the owner", Description: "No order can be cancelled yet, so cancelledBookings is zero.", Auth: true, Roles: ownerRoles, Query: HTTPVenueStatsQuery{}, Data: HTTPVenueStats{}},
+	{Method: http.MethodGet, Path: "/v1/owner/package/:id/price-rules", Tag: tagOwner, Summary: "List the price rules of a package", Auth: true, Roles: ownerRoles, Data: HTTPPriceRules{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPost, Path: "/v1/owner/package/:id/price-rules", Tag: tagOwner, Summary: "Add a price rule to a package", Description: "day_of_week rules need daysOfWeek (0 = sunday), date_range rules startDate and endDate, specific_date rules startDate and lead_time rules minLeadDays and maxLeadDays (0 = unbounded).", Auth: true, Roles: ownerRoles, Body: HTTPPriceRule{}, Data: HTTPPriceRuleResp{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/v1/owner/price-rules/:id", Tag: tagOwner, Summary: "Update a price rule", Auth: true, Roles: ownerRoles, Body: HTTPPriceRule{}, Data: HTTPPriceRuleResp{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/v1/owner/price-rules/:id", Tag: tagOwner, Summary: "Delete a price rule", Auth: true, Roles: ownerRoles, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+	{Method: http.MethodGet, Path: "/v1/admin/promo", Tag: tagAdmin, Summary: "List the promos", Auth: true, Roles: adminRoles, Query: HTTPPromosQuery{}, Data: HTTPPromos{}},
+	{Method: http.MethodPost, Path: "/v1/admin/promo", Tag: tagAdmin, Summary: "Create a promo", Auth: true, Roles: adminRoles, Body: HTTPPromo{}, Data: HTTPPromoResp{}, Errors: []int{http.StatusConflict}},
+	{Method: http.MethodPut, Path: "/v1/admin/promo/:id", Tag: tagAdmin, Summary: "Update a promo", Auth: true, Roles: adminRoles, Body: HTTPPromo{}, Data: HTTPPromoResp{}, Errors: []int{http.StatusNotFound, http.StatusConflict}},
+	{Method: http.MethodPost, Path: "/v1/admin/orders/:id/paid", Tag: tagAdmin, Summary: "Mark an order as paid", Description: "The invoice of the order is then issued as a receipt. Marking a paid order again changes nothing.", Auth: true, Roles: adminRoles, Data: HTTPOrderResp{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPost, Path: "/v1/admin/venue/:id/calendar-token", Tag: tagAdmin, Summary: "Rotate the calendar feed token of a venue", Auth: true, Roles: adminRoles, Data: HTTPVenueCalendarFeed{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodPut, Path: "/v1/admin/venue/:id/owners/:userId", Tag: tagAdmin, Summary: "Make a user an owner of a venue", Description: "A customer becomes an owner, the role is in the tokens issued from then on so the user logs in again.", Auth: true, Roles: adminRoles, Errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{Method: http.MethodDelete, Path: "/v1/admin/venue/:id/owners/:userId", Tag: tagAdmin, Summary: "Remove a user from the owners of a venue", Auth: true, Roles: adminRoles, Errors: []int{http.StatusBadRequest}},
+	{
+		Method:    http.MethodGet,
+		Path:      "/v1/admin/content/:type/:id/translations",
+		Tag:       tagAdmin,
+		Summary:   "List the translations of a content",
+		Auth:      true,
+		Roles:     adminRoles,
+		Data:      HTTPTranslationsResp{},
+		PathEnums: map[string][]string{"type": contentTypes},
+		Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		Method:      http.MethodPut,
+		Path:        "/v1/admin/content/:type/:id/translations/:locale",
+		Tag:         tagAdmin,
+		Summary:     "Translate a content",
+		Description: "An empty value removes the translation of the field.",
+		Auth:        true,
+		Roles:       adminRoles,
+		Body:        HTTPTranslations{},
+		Data:        HTTPTranslationsResp{},
+		PathEnums:   map[string][]string{"type": contentTypes},
+		Errors:      []int{http.StatusNotFound},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/v1/admin/reports/:name",
+		Tag:         tagAdmin,
+		Summary:     "Get or export a report",
+		Description: "Bookings are dated by the booked day. JSON is paginated, CSV and XLSX stream every row. No order can be cancelled yet, so the cancellation figures are zero.",
+		Auth:        true,
+		Roles:       adminRoles,
+		Query:       HTTPReportQuery{},
+		Data:        HTTPReport{},
+		Produces:    []string{"text/csv", xlsx.ContentType},
+		PathEnums:   map[string][]string{"name": {entity.ReportBookings, entity.ReportRevenue, entity.ReportCancellations, entity.ReportRegistrations}},
+		Errors:      []int{http.StatusNotFound},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/v1/admin/venues/import",
+		Tag:         tagAdmin,
+		Summary:     "Import venues",
+		Description: "Takes a multipart upload in the file field or the raw body, nothing is written when a record is invalid.",
+		Auth:        true,
+		Roles:       adminRoles,
+		Query:       HTTPImportQuery{},
+		BodyTypes: map[string]*openapi.Schema{
+			"multipart/form-data": {Type: "object", Properties: map[string]*openapi.Schema{"file": {Type: "string", Format: "binary"}}, Required: []string{"file"}},
+			"text/csv":            {Type: "string"},
+			"application/json":    {Type: "object"},
+		},
+		Data:   HTTPImportResult{},
+		Errors: []int{http.StatusUnprocessableEntity},
+	},
+}
+
+// Spec returns the OpenAPI document of the API.
+func (h *HTTPHandler) Spec() *openapi.Document {
+	return h.spec
+}
+
+func (h *HTTPHandler) OpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, h.spec)
+}
+
+// SwaggerUI serves a Swagger UI of the document, its assets come from a CDN.
+func (h *HTTPHandler) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+}
+
+func newSpec() *openapi.Document {
+	return openapi.New(openapi.Info{
+		Title:       "Venue API",
+		Description: "Venue discovery and booking. Errors carry a stable meta.errorCode, messages follow Accept-Language (id or en).",
+		Version:     "1.0.0",
+	}, api.Response{}, "data", routes)
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Venue API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>
+window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// credential returns the authenticated user set by the auth middleware.
+func credential(c *gin.Context) (*entity.CredentialClaim, error) {
+	id, ok := c.Get("id")
+	if !ok {
+		return nil, errutil.NewWithCode(errutil.ErrUnauthorized, entity.ErrCodeInvalidToken, fmt.Errorf("can't extract user id"))
+	}
+	return &entity.CredentialClaim{
+		ID:       id.(int),
+		Email:    c.GetString("email"),
+		FullName: c.GetString("fullname"),
+		Role:     c.GetString("role"),
+		Locale:   c.GetString("locale"),
+	}, nil
+}
+
+func (h *HTTPHandler) GetOrderInvoice(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	file, err := h.usecase.GetOrderInvoice(c.Request.Context(), idInt, user)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (h *HTTPHandler) GetOrderCalendar(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	file, err := h.usecase.GetOrderCalendar(c.Request.Context(), idInt, user)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// HTTPVenueCalendarQuery is the query string of GetVenueCalendar.
+func (h *HTTPHandler) MarkOrderPaid(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+
+	order, err := h.usecase.MarkOrderPaid(c.Request.Context(), idInt)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPOrderResp{
+		Order: order,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+type HTTPVenueCalendarQuery struct {
+	Token string `form:"token"`
+}
+
+func (h *HTTPHandler) GetVenueCalendar(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var query HTTPVenueCalendarQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	file, err := h.usecase.GetVenueCalendar(c.Request.Context(), idInt, query.Token)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+type HTTPVenueCalendarFeed struct {
+	Token   string `json:"token"`
+	FeedURL string `json:"feedUrl"`
+}
+
+func (h *HTTPHandler) RotateVenueCalendarToken(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+
+	token, err := h.usecase.RotateVenueCalendarToken(c.Request.Context(), idInt)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	api.ResponseSuccess(c, HTTPVenueCalendarFeed{
+		Token:   token,
+		FeedURL: fmt.Sprintf("%s://%s/v1/venue/%d/calendar.ics?token=%s", scheme, c.Request.Host, idInt, token),
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPOwnerOrders struct {
+	Orders []*entity.OwnerOrder `json:"orders"`
+}
+
+// HTTPOwnerOrdersQuery is the query string of GetOwnerOrders.
+type HTTPOwnerOrdersQuery struct {
+	VenueID   int       `form:"venueId" binding:"omitempty,gt=0"`
+	PackageID int       `form:"packageId" binding:"omitempty,gt=0"`
+	Status    string    `form:"status" binding:"omitempty,oneof=confirmed cancelled"`
+	StartDate time.Time `form:"startDate" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"endDate" time_format:"2006-01-02" time_utc:"1"`
+	Page      int       `form:"page" binding:"omitempty,gte=1"`
+	Limit     int       `form:"limit" binding:"omitempty,gte=1"`
+}
+
+func (h *HTTPHandler) GetOwnerVenues(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	result, err := h.usecase.GetOwnerVenues(c.Request.Context(), user)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPVenues{
+		Venues: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+func (h *HTTPHandler) GetOwnerOrders(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	var query HTTPOwnerOrdersQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	param := &entity.OwnerOrderQuery{
+		PackageID: query.PackageID,
+		Status:    query.Status,
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+		Page:      query.Page,
+		Limit:     h.pagination.Limit(query.Limit),
+	}
+
+	result, pag, err := h.usecase.GetOwnerOrders(c.Request.Context(), user, query.VenueID, param)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPOwnerOrders{
+		Orders: result,
+	}, &api.ResponseMeta{
+		Status:       "success",
+		Code:         http.StatusOK,
+		Page:         pag.Page,
+		TotalPage:    pag.TotalPage,
+		CurrentItems: pag.CurrentItems,
+		TotalItems:   pag.TotalItems,
+	})
+}
+
+type HTTPVenueOccupancy struct {
+	Days []*entity.OccupancyDay `json:"days"`
+}
+
+// HTTPVenueOccupancyQuery is the query string of GetVenueOccupancy.
+type HTTPVenueOccupancyQuery struct {
+	Month time.Time `form:"month" time_format:"2006-01" time_utc:"1"`
+}
+
+func (h *HTTPHandler) GetVenueOccupancy(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var query HTTPVenueOccupancyQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	month := query.Month
+	if month.IsZero() {
+		month = time.Now()
+	}
+
+	result, err := h.usecase.GetVenueOccupancy(c.Request.Context(), user, idInt, month)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPVenueOccupancy{
+		Days: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+type HTTPVenueStats struct {
+	Stats *entity.VenueStats `json:"stats"`
+}
+
+// HTTPVenueStatsQuery is the query string of GetVenueStats.
+type HTTPVenueStatsQuery struct {
+	VenueID   int       `form:"venueId" binding:"omitempty,gt=0"`
+	StartDate time.Time `form:"startDate" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"endDate" time_format:"2006-01-02" time_utc:"1"`
+}
+
+func (h *HTTPHandler) GetVenueStats(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	var query HTTPVenueStatsQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	result, err := h.usecase.GetVenueStats(c.Request.Context(), user, query.VenueID, query.StartDate, query.EndDate)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPVenueStats{
+		Stats: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+type HTTPPriceRule struct {
+	Data *entity.PriceRule `json:"data" binding:"required"`
+}
+
+type HTTPPriceRuleResp struct {
+	PriceRule *entity.PriceRule `json:"priceRule"`
+}
+
+type HTTPPriceRules struct {
+	PriceRules []*entity.PriceRule `json:"priceRules"`
+}
+
+func (h *HTTPHandler) GetPriceRules(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+
+	result, err := h.usecase.GetPriceRules(c.Request.Context(), user, idInt)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPPriceRules{
+		PriceRules: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+func (h *HTTPHandler) CreatePriceRule(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var payload HTTPPriceRule
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	payload.Data.PackageID = idInt
+	err = h.usecase.CreatePriceRule(c.Request.Context(), user, payload.Data)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPPriceRuleResp{
+		PriceRule: payload.Data,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusCreated,
+	})
+}
+
+func (h *HTTPHandler) UpdatePriceRule(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var payload HTTPPriceRule
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	payload.Data.ID = idInt
+	err = h.usecase.UpdatePriceRule(c.Request.Context(), user, payload.Data)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPPriceRuleResp{
+		PriceRule: payload.Data,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+func (h *HTTPHandler) DeletePriceRule(c *gin.Context) {
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	err = h.usecase.DeletePriceRule(c.Request.Context(), user, idInt)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, nil, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+// venueOwnerIDs reads the venue and user ids of the owner assignment routes.
+func venueOwnerIDs(c *gin.Context) (int, int, error) {
+	venueID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format"))
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		return 0, 0, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid userId format"))
+	}
+	return venueID, userID, nil
+}
+
+func (h *HTTPHandler) AssignVenueOwner(c *gin.Context) {
+	venueID, userID, err := venueOwnerIDs(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	err = h.usecase.AssignVenueOwner(c.Request.Context(), venueID, userID)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, nil, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+func (h *HTTPHandler) RemoveVenueOwner(c *gin.Context) {
+	venueID, userID, err := venueOwnerIDs(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	err = h.usecase.RemoveVenueOwner(c.Request.Context(), venueID, userID)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, nil, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type HTTPValidatePromoResp struct {
+	Order *entity.Order `json:"order"`
+}
+
+func (h *HTTPHandler) ValidatePromo(c *gin.Context) {
+	var payload HTTPOrder
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	order, err := h.toOrder(c, payload.Data)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	err = h.usecase.ValidatePromo(c.Request.Context(), order)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPValidatePromoResp{
+		Order: order,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+type HTTPPromo struct {
+	Data *entity.Promo `json:"data" binding:"required"`
+}
+
+type HTTPPromoResp struct {
+	Promo *entity.Promo `json:"promo"`
+}
+
+type HTTPPromos struct {
+	Promos []*entity.Promo `json:"promos"`
+}
+
+// HTTPPromosQuery is the query string of GetPromos.
+type HTTPPromosQuery struct {
+	Code     string `form:"code"`
+	IsActive bool   `form:"isActive"`
+	Page     int    `form:"page" binding:"omitempty,gte=1"`
+	Limit    int    `form:"limit" binding:"omitempty,gte=1"`
+}
+
+func (h *HTTPHandler) GetPromos(c *gin.Context) {
+	var query HTTPPromosQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	param := entity.GetPromosParam{
+		Code:     query.Code,
+		IsActive: query.IsActive,
+		Page:     query.Page,
+		Limit:    h.pagination.Limit(query.Limit),
+	}
+
+	result, pag, err := h.usecase.GetPromos(c.Request.Context(), param)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPPromos{
+		Promos: result,
+	}, &api.ResponseMeta{
+		Status:       "success",
+		Code:         http.StatusOK,
+		Page:         pag.Page,
+		TotalPage:    pag.TotalPage,
+		CurrentItems: pag.CurrentItems,
+		TotalItems:   pag.TotalItems,
+	})
+}
+
+func (h *HTTPHandler) CreatePromo(c *gin.Context) {
+	var payload HTTPPromo
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	err := h.usecase.CreatePromo(c.Request.Context(), payload.Data)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPPromoResp{
+		Promo: payload.Data,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusCreated,
+	})
+}
+
+func (h *HTTPHandler) UpdatePromo(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var payload HTTPPromo
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	payload.Data.ID = idInt
+	err = h.usecase.UpdatePromo(c.Request.Context(), payload.Data)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPPromoResp{
+		Promo: payload.Data,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
+	"github.com/faruqfadhil/venue-api/pkg/xlsx"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+	reportFormatXLSX = "xlsx"
+)
+
+type HTTPReport struct {
+	Report *entity.Report `json:"report"`
+}
+
+// reportExporter streams a report to the response. Headers are only sent on
+// the first record so a rejected query can still be answered with JSON.
+type reportExporter struct {
+	c        *gin.Context
+	format   string
+	filename string
+	csv      *csv.Writer
+	xlsx     *xlsx.Writer
+}
+
+func (e *reportExporter) Write(record []string) error {
+	if e.csv == nil && e.xlsx == nil {
+		e.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.filename))
+		switch e.format {
+		case reportFormatCSV:
+			e.c.Header("Content-Type", "text/csv; charset=utf-8")
+			e.c.Status(http.StatusOK)
+			e.csv = csv.NewWriter(e.c.Writer)
+		case reportFormatXLSX:
+			e.c.Header("Content-Type", xlsx.ContentType)
+			e.c.Status(http.StatusOK)
+			w, err := xlsx.NewWriter(e.c.Writer, "Report")
+			if err != nil {
+				return err
+			}
+			e.xlsx = w
+		}
+	}
+	if e.csv != nil {
+		return e.csv.Write(record)
+	}
+	return e.xlsx.Write(record)
+}
+
+func (e *reportExporter) started() bool {
+	return e.csv != nil || e.xlsx != nil
+}
+
+func (e *reportExporter) Close() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	if e.xlsx != nil {
+		return e.xlsx.Close()
+	}
+	return nil
+}
+
+// HTTPReportQuery is the query string of GetReport.
+type HTTPReportQuery struct {
+	GroupBy   string    `form:"groupBy"`
+	StartDate time.Time `form:"startDate" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time `form:"endDate" time_format:"2006-01-02" time_utc:"1"`
+	Format    string    `form:"format" binding:"omitempty,oneof=json csv xlsx"`
+	Page      int       `form:"page" binding:"omitempty,gte=1"`
+	Limit     int       `form:"limit" binding:"omitempty,gte=1"`
+}
+
+func (h *HTTPHandler) GetReport(c *gin.Context) {
+	name := c.Param("name")
+	var query HTTPReportQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	param := &entity.ReportQuery{
+		GroupBy:   query.GroupBy,
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+	}
+
+	if query.Format == reportFormatCSV || query.Format == reportFormatXLSX {
+		h.exportReport(c, name, query.Format, param)
+		return
+	}
+
+	param.Page, param.Limit = query.Page, h.pagination.Limit(query.Limit)
+	result, pag, err := h.usecase.GetReport(c.Request.Context(), name, param)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPReport{
+		Report: result,
+	}, &api.ResponseMeta{
+		Status:       "success",
+		Code:         http.StatusOK,
+		Page:         pag.Page,
+		TotalPage:    pag.TotalPage,
+		CurrentItems: pag.CurrentItems,
+		TotalItems:   pag.TotalItems,
+	})
+}
+
+func (h *HTTPHandler) exportReport(c *gin.Context, name, format string, param *entity.ReportQuery) {
+	exporter := &reportExporter{
+		c:        c,
+		format:   format,
+		filename: fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format),
+	}
+	err := h.usecase.ExportReport(c.Request.Context(), name, param, exporter)
+	if err != nil && !exporter.started() {
+		api.ResponseFailed(c, err)
+		return
+	}
+	if closeErr := exporter.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// The body is already partially sent, all that can be done is to
+		// record the failure and leave the download truncated.
+		ctx := c.Request.Context()
+		logger.FromContext(ctx).ErrorContext(ctx, "report export interrupted",
+			slog.String("report", name),
+			slog.String("format", format),
+			slog.String("error", errutil.GetOriginalErr(err).Error()),
+		)
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
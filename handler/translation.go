@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPTranslations is the body of UpdateTranslations, the translated value
+// of each field, empty to remove it.
+type HTTPTranslations struct {
+	Data map[string]string `json:"data" binding:"required,min=1"`
+}
+
+type HTTPTranslationsResp struct {
+	Content *entity.ContentTranslations `json:"content"`
+}
+
+func (h *HTTPHandler) GetTranslations(c *gin.Context) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+
+	result, err := h.usecase.GetTranslations(c.Request.Context(), c.Param("type"), idInt)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPTranslationsResp{
+		Content: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+func (h *HTTPHandler) UpdateTranslations(c *gin.Context) {
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var payload HTTPTranslations
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	result, err := h.usecase.UpdateTranslations(c.Request.Context(), c.Param("type"), idInt, c.Param("locale"), payload.Data)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPTranslationsResp{
+		Content: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -13,55 +13,73 @@ import (
 	"github.com/faruqfadhil/venue-api/core/entity"
 	"github.com/faruqfadhil/venue-api/core/module"
 	"github.com/faruqfadhil/venue-api/pkg/api"
+	"github.com/faruqfadhil/venue-api/pkg/config"
+	"github.com/faruqfadhil/venue-api/pkg/health"
+	"github.com/faruqfadhil/venue-api/pkg/openapi"
+	"github.com/faruqfadhil/venue-api/pkg/validation"
 	"github.com/gin-gonic/gin"
 )
 
 type HTTPHandler struct {
 	usecase module.Usecase
+	health  *health.Checker
+	spec    *openapi.Document
+	// pagination bounds the page size clients ask for, the usecase and the
+	// repository use the limit they are given.
+	pagination config.Pagination
 }
 
-func New(uc module.Usecase) *HTTPHandler {
+func New(uc module.Usecase, checker *health.Checker, pagination config.Pagination) *HTTPHandler {
 	return &HTTPHandler{
-		usecase: uc,
+		usecase:    uc,
+		health:     checker,
+		spec:       newSpec(),
+		pagination: pagination,
 	}
 }
 
 func (h *HTTPHandler) GetCities(c *gin.Context) {
-	cities, err := h.usecase.GetCities(context.Background())
+	cities, err := h.usecase.GetCities(c.Request.Context())
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
 	}
 
-	api.ResponseSuccess(c, cities, &api.ResponseMeta{
+	var updatedAt time.Time
+	for _, ct := range cities {
+		if ct.UpdatedAt.After(updatedAt) {
+			updatedAt = ct.UpdatedAt
+		}
+	}
+	api.ResponseCacheable(c, cities, &api.ResponseMeta{
 		Status: "success",
 		Code:   http.StatusOK,
-	})
+	}, updatedAt)
 }
 
 type HTTPRegister struct {
-	Data *entity.User `json:"data"`
+	Data *HTTPRegisterData `json:"data" binding:"required"`
+}
+
+type HTTPRegisterData struct {
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,password"`
+	FullName string `json:"fullname" binding:"required,max=255"`
+	Locale   string `json:"locale" binding:"omitempty,oneof=id en"`
 }
 
 func (h *HTTPHandler) Register(c *gin.Context) {
-	var payload *HTTPRegister
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		api.ResponseFailed(c, errutil.ErrGeneralBadRequest)
-		return
-	}
-	if strings.TrimSpace(payload.Data.Email) == "" {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("email can't be empty"), "email tidak boleh kosong"))
-		return
-	}
-	if strings.TrimSpace(payload.Data.Password) == "" {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("password can't be empty"), "password tidak boleh kosong"))
-		return
-	}
-	if strings.TrimSpace(payload.Data.FullName) == "" {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("fullname can't be empty"), "fullname tidak boleh kosong"))
+	var payload HTTPRegister
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
 		return
 	}
-	err := h.usecase.Register(context.Background(), payload.Data)
+	err := h.usecase.Register(c.Request.Context(), &entity.User{
+		Email:    strings.TrimSpace(payload.Data.Email),
+		Password: payload.Data.Password,
+		FullName: strings.TrimSpace(payload.Data.FullName),
+		Locale:   payload.Data.Locale,
+	})
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
@@ -74,12 +92,12 @@ func (h *HTTPHandler) Register(c *gin.Context) {
 }
 
 type HTTPLogin struct {
-	Data *HTTPLoginData `json:"data"`
+	Data *HTTPLoginData `json:"data" binding:"required"`
 }
 
 type HTTPLoginData struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
 }
 
 type HTTPLoginResp struct {
@@ -87,20 +105,12 @@ type HTTPLoginResp struct {
 }
 
 func (h *HTTPHandler) Login(c *gin.Context) {
-	var payload *HTTPLogin
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		api.ResponseFailed(c, errutil.ErrGeneralBadRequest)
-		return
-	}
-	if strings.TrimSpace(payload.Data.Email) == "" {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("email can't be empty"), "email tidak boleh kosong"))
-		return
-	}
-	if strings.TrimSpace(payload.Data.Password) == "" {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("password can't be empty"), "password tidak boleh kosong"))
+	var payload HTTPLogin
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
 		return
 	}
-	authInfo, err := h.usecase.Login(context.Background(), payload.Data.Email, payload.Data.Password)
+	authInfo, err := h.usecase.Login(c.Request.Context(), payload.Data.Email, payload.Data.Password)
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
@@ -116,48 +126,105 @@ func (h *HTTPHandler) Login(c *gin.Context) {
 	})
 }
 
-type HTTPOrder struct {
-	Data *HTTPOrderData `json:"data"`
+type HTTPLocale struct {
+	Data *HTTPLocaleData `json:"data" binding:"required"`
 }
-type HTTPOrderData struct {
-	PackageID int    `json:"packageId"`
-	Date      string `json:"date"`
+
+type HTTPLocaleData struct {
+	// Locale is empty to follow the Accept-Language header again.
+	Locale string `json:"locale" binding:"omitempty,oneof=id en"`
 }
 
-func (h *HTTPHandler) CreateOrder(c *gin.Context) {
-	var payload *HTTPOrder
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		api.ResponseFailed(c, errutil.ErrGeneralBadRequest)
+// UpdateLocale saves the preferred locale of the messages of the user and
+// returns a new token carrying it.
+func (h *HTTPHandler) UpdateLocale(c *gin.Context) {
+	var payload HTTPLocale
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
 		return
 	}
-	if strings.TrimSpace(payload.Data.Date) == "" {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("date can't be empty"), "tanggal tidak boleh kosong"))
+	user, err := credential(c)
+	if err != nil {
+		api.ResponseFailed(c, err)
 		return
 	}
-	if payload.Data.PackageID < 1 {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("package id can't be empty"), "package id tidak boleh kosong"))
+	authInfo, err := h.usecase.UpdateLocale(c.Request.Context(), user, payload.Data.Locale)
+	if err != nil {
+		api.ResponseFailed(c, err)
 		return
 	}
-	date, err := time.Parse("2006-01-02", payload.Data.Date)
+
+	api.ResponseSuccess(c, HTTPLoginResp{Account: authInfo}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
+	})
+}
+
+type HTTPOrder struct {
+	Data *HTTPOrderData `json:"data" binding:"required"`
+}
+type HTTPOrderData struct {
+	PackageID int                   `json:"packageId" binding:"required,gt=0"`
+	Date      string                `json:"date" binding:"required,date"`
+	Addons    []*HTTPOrderAddonData `json:"addons" binding:"dive,required"`
+	PromoCode string                `json:"promoCode" binding:"max=50"`
+}
+
+type HTTPOrderAddonData struct {
+	AddonID  int `json:"addonId" binding:"required,gt=0"`
+	Quantity int `json:"quantity" binding:"gte=1"`
+}
+
+type HTTPOrderResp struct {
+	Order *entity.Order `json:"order"`
+}
+
+// toOrder converts a validated order payload into an order of the
+// authenticated user.
+func (h *HTTPHandler) toOrder(c *gin.Context, data *HTTPOrderData) (*entity.Order, error) {
+	date, err := time.Parse("2006-01-02", data.Date)
 	if err != nil {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid date format"), "format tanggal harus YYYY-MM-DD"))
-		return
+		return nil, errutil.New(errutil.ErrGeneralBadRequest, err)
 	}
 	if _, ok := c.Get("id"); !ok {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("can't extract user id"), "tidak dapat mengekstrak user id"))
-		return
+		return nil, errutil.NewWithCode(errutil.ErrUnauthorized, entity.ErrCodeInvalidToken, fmt.Errorf("can't extract user id"))
+	}
+	addons := []*entity.OrderAddon{}
+	for _, ad := range data.Addons {
+		addons = append(addons, &entity.OrderAddon{
+			AddonID:  ad.AddonID,
+			Quantity: ad.Quantity,
+		})
 	}
 	userID, _ := c.Get("id")
-	err = h.usecase.Order(context.Background(), &entity.Order{
-		PackageID: payload.Data.PackageID,
+	return &entity.Order{
+		PackageID: data.PackageID,
 		UserID:    userID.(int),
 		Date:      date,
-	})
+		Addons:    addons,
+		PromoCode: strings.TrimSpace(data.PromoCode),
+	}, nil
+}
+
+func (h *HTTPHandler) CreateOrder(c *gin.Context) {
+	var payload HTTPOrder
+	if err := validation.BindJSON(c, &payload); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	order, err := h.toOrder(c, payload.Data)
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
 	}
-	api.ResponseSuccess(c, nil, &api.ResponseMeta{
+	err = h.usecase.Order(c.Request.Context(), order)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+	api.ResponseSuccess(c, HTTPOrderResp{
+		Order: order,
+	}, &api.ResponseMeta{
 		Status: "success",
 		Code:   http.StatusOK,
 	})
@@ -167,78 +234,75 @@ type HTTPVenues struct {
 	Venues []*entity.Venue `json:"venues"`
 }
 
-func (h *HTTPHandler) GetVenues(c *gin.Context) {
-	var (
-		cityID      int
-		isFavourite bool
-		date        time.Time
-		page        int
-		limit       int
-	)
-	cityIDQ := c.Query("cityId")
-	if cityIDQ != "" {
-		city, err := strconv.Atoi(cityIDQ)
-		if err != nil {
-			api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid date format"), "format city id tidak valid"))
-			return
-		}
-		cityID = city
-	}
+// HTTPVenuesQuery is the query string of GetVenues.
+type HTTPVenuesQuery struct {
+	CityID      int       `form:"cityId" binding:"omitempty,gt=0"`
+	IsFavourite bool      `form:"isFavourite"`
+	Date        time.Time `form:"date" time_format:"2006-01-02" time_utc:"1"`
+	Page        int       `form:"page" binding:"omitempty,gte=1"`
+	Limit       int       `form:"limit" binding:"omitempty,gte=1"`
+	Sort        string    `form:"sort" binding:"omitempty,oneof=id -id price -price star -star capacity -capacity"`
+	Cursor      string    `form:"cursor"`
+	SkipCount   bool      `form:"skipCount"`
+	Fields      string    `form:"fields" binding:"omitempty,each_oneof=id name minPrice maxPrice capacity star reviewCount thumbnailUrl cityID city description website phone email instagram address logo isFavourite gallery timezone"`
+}
 
-	isF, ok := c.GetQuery("isFavourite")
-	if ok && isF == "true" {
-		isFavourite = true
-	}
-	d := c.Query("date")
-	if d != "" {
-		dn, err := time.Parse("2006-01-02", d)
-		if err != nil {
-			api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid date format"), "format tanggal harus YYYY-MM-DD"))
-			return
-		}
-		date = dn
+// HTTPVenueFields is the answer of GetVenues when only some fields are asked.
+type HTTPVenueFields struct {
+	Venues []map[string]json.RawMessage `json:"venues"`
+}
+
+func (h *HTTPHandler) GetVenues(c *gin.Context) {
+	var query HTTPVenuesQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
 	}
 
-	pageQ := c.Query("page")
-	if pageQ != "" {
-		t, err := strconv.Atoi(pageQ)
-		if err != nil {
-			api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid date format"), "format page tidak valid"))
-			return
+	var fields []string
+	if query.Fields != "" {
+		for _, f := range strings.Split(query.Fields, ",") {
+			fields = append(fields, strings.TrimSpace(f))
 		}
-		page = t
-	}
-	limitQ := c.Query("limit")
-	if limitQ != "" {
-		t, err := strconv.Atoi(limitQ)
-		if err != nil {
-			api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid date format"), "format limit tidak valid"))
-			return
-		}
-		limit = t
 	}
 
-	result, pag, err := h.usecase.GetVenues(c, entity.GetVenuesParam{
-		CityID:      cityID,
-		IsFavourite: isFavourite,
-		Date:        date,
-		Page:        page,
-		Limit:       limit,
+	result, pag, err := h.usecase.GetVenues(c.Request.Context(), entity.GetVenuesParam{
+		CityID:      query.CityID,
+		IsFavourite: query.IsFavourite,
+		Date:        query.Date,
+		Page:        query.Page,
+		Limit:       h.pagination.Limit(query.Limit),
+		Sort:        query.Sort,
+		Cursor:      query.Cursor,
+		SkipCount:   query.SkipCount,
+		Fields:      fields,
 	})
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
 	}
 
-	api.ResponseSuccess(c, HTTPVenues{
-		Venues: result,
-	}, &api.ResponseMeta{
+	var out interface{} = HTTPVenues{Venues: result}
+	if len(fields) > 0 {
+		venues := make([]map[string]json.RawMessage, 0, len(result))
+		for _, vn := range result {
+			v, err := project(vn, fields)
+			if err != nil {
+				api.ResponseFailed(c, errutil.New(errutil.ErrInternal, err))
+				return
+			}
+			venues = append(venues, v)
+		}
+		out = HTTPVenueFields{Venues: venues}
+	}
+	api.ResponseSuccess(c, out, &api.ResponseMeta{
 		Status:       "success",
 		Code:         http.StatusOK,
 		Page:         pag.Page,
 		TotalPage:    pag.TotalPage,
 		CurrentItems: pag.CurrentItems,
 		TotalItems:   pag.TotalItems,
+		NextCursor:   pag.NextCursor,
 	})
 }
 
@@ -247,7 +311,7 @@ type HTTPGetNearby struct {
 }
 
 func (h *HTTPHandler) GetNearby(c *gin.Context) {
-	result, err := h.usecase.GetVenuesNearby(context.Background())
+	result, err := h.usecase.GetVenuesNearby(c.Request.Context())
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
@@ -269,22 +333,22 @@ func (h *HTTPHandler) GetVenueDetail(c *gin.Context) {
 	id := c.Param("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid id format"), "format id tidak valid"))
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
 		return
 	}
 
-	result, err := h.usecase.GetVenueByID(c, idInt)
+	result, err := h.usecase.GetVenueByID(c.Request.Context(), idInt)
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
 	}
 
-	api.ResponseSuccess(c, HTTPGetVenueDetail{
+	api.ResponseCacheable(c, HTTPGetVenueDetail{
 		Venue: result,
 	}, &api.ResponseMeta{
 		Status: "success",
 		Code:   http.StatusOK,
-	})
+	}, result.UpdatedAt)
 }
 
 type HTTPGetPackageDetail struct {
@@ -295,20 +359,77 @@ func (h *HTTPHandler) GetPackageDetail(c *gin.Context) {
 	id := c.Param("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		api.ResponseFailed(c, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("invalid id format"), "format id tidak valid"))
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
 		return
 	}
 
-	result, err := h.usecase.GetPackageByID(c, idInt)
+	result, err := h.usecase.GetPackageByID(c.Request.Context(), idInt)
 	if err != nil {
 		api.ResponseFailed(c, err)
 		return
 	}
 
-	api.ResponseSuccess(c, HTTPGetPackageDetail{
+	// The gallery of the venue and removed addons leave no updated_at behind,
+	// so the package is only tagged with an ETag.
+	api.ResponseCacheable(c, HTTPGetPackageDetail{
 		Package: result,
 	}, &api.ResponseMeta{
 		Status: "success",
 		Code:   http.StatusOK,
+	}, time.Time{})
+}
+
+type HTTPGetPackagePrice struct {
+	Price *entity.PackagePrice `json:"price"`
+}
+
+// HTTPPackagePriceQuery is the query string of GetPackagePrice.
+type HTTPPackagePriceQuery struct {
+	Date time.Time `form:"date" time_format:"2006-01-02" time_utc:"1"`
+}
+
+func (h *HTTPHandler) GetPackagePrice(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		api.ResponseFailed(c, errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidID, fmt.Errorf("invalid id format")))
+		return
+	}
+	var query HTTPPackagePriceQuery
+	if err := validation.BindQuery(c, &query); err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	result, err := h.usecase.GetPackagePrice(c.Request.Context(), idInt, query.Date)
+	if err != nil {
+		api.ResponseFailed(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, HTTPGetPackagePrice{
+		Price: result,
+	}, &api.ResponseMeta{
+		Status: "success",
+		Code:   http.StatusOK,
 	})
 }
+
+// project keeps the given JSON fields of v.
+func project(v interface{}, fields []string) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("[project] err: %v", err)
+	}
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, fmt.Errorf("[project] err: %v", err)
+	}
+	out := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if raw, ok := all[f]; ok {
+			out[f] = raw
+		}
+	}
+	return out, nil
+}
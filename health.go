@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/pkg/health"
+	"github.com/faruqfadhil/venue-api/pkg/worker"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// newHealthChecker checks that the database answers, its schema is at the
+// version this build expects and background work is still accepted.
+func newHealthChecker(sqlDB *sql.DB, workers *worker.Group) (*health.Checker, error) {
+	m, err := newMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	checker := health.New(readinessTimeout)
+	checker.Add("database", sqlDB.PingContext)
+	checker.Add("migrations", func(ctx context.Context) error {
+		version, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if version != m.Latest() {
+			return fmt.Errorf("schema is at version %d, expected %d", version, m.Latest())
+		}
+		return nil
+	})
+	checker.Add("workers", func(ctx context.Context) error {
+		if !workers.Running() {
+			return errors.New("background workers are stopped")
+		}
+		return nil
+	})
+	return checker, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/core/module"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+)
+
+// runImport implements `venue-api import [flags] FILE`, it prints the import
+// result as JSON and returns the process exit code.
+func runImport(usecase module.Usecase, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "validate and report without committing")
+	format := fs.String("format", "", "csv or json, defaults to the file extension")
+	by := fs.String("by", "cli", "value written to created_by and updated_by")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: venue-api import [flags] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	path := fs.Arg(0)
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to open %s: %v\n", path, err)
+		return 1
+	}
+	defer f.Close()
+
+	param := &entity.ImportParam{
+		Format:     *format,
+		DryRun:     *dryRun,
+		ImportedBy: *by,
+	}
+	if param.Format == "" {
+		param.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	result, err := usecase.ImportVenues(context.Background(), f, param)
+	if err != nil {
+		var intErr *errutil.InternalError
+		if errors.As(err, &intErr) && intErr.OriginalErr != nil {
+			err = fmt.Errorf("%s: %v", i18n.Error(i18n.Default, intErr), intErr.OriginalErr)
+		}
+		fmt.Fprintf(os.Stderr, "import failed: %v\n", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+	if len(result.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -1,70 +1,204 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/faruqfadhil/venue-api/core/module"
+	repoInterface "github.com/faruqfadhil/venue-api/core/repository"
 	"github.com/faruqfadhil/venue-api/handler"
 	"github.com/faruqfadhil/venue-api/pkg/api"
+	"github.com/faruqfadhil/venue-api/pkg/cache"
+	"github.com/faruqfadhil/venue-api/pkg/config"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+	"github.com/faruqfadhil/venue-api/pkg/tracing"
+	"github.com/faruqfadhil/venue-api/pkg/worker"
+	"github.com/faruqfadhil/venue-api/repository/cached"
+	"github.com/faruqfadhil/venue-api/repository/instrumented"
 	venueRepo "github.com/faruqfadhil/venue-api/repository/venue"
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin" 
-	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
+const maxConnectBackoff = 10 * time.Second
+
 func main() {
-	// Config CORS
-	config := cors.DefaultConfig()
-	config.AllowHeaders = []string{"Authorization"}
-	config.AllowOrigins = []string{"http://localhost:3000"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("unable to load config, err: %v", err)
+	}
+	appLog := logger.New(os.Stderr, cfg.Log)
+	slog.SetDefault(appLog)
 
-	// Create a new CORS middleware instance with default options
-	c := cors.New(config)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal(appLog, "unable to set up tracing", err)
+	}
 
-	err := godotenv.Load()
+	db := conn(cfg, appLog)
+	if err := db.Use(&tracing.GormPlugin{DBName: cfg.MySQL.Database}); err != nil {
+		fatal(appLog, "unable to instrument db", err)
+	}
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("unable to load env, err: %v", err)
-	}
-	db := conn()
-	repo := venueRepo.New(db)
-	usecase := module.New(repo)
-	hdlr := handler.New(usecase)
-	middlewareSvc := api.NewMiddlewareService(usecase)
-	router := gin.Default()
-	// use CORS
-	router.Use(c)
+		fatal(appLog, "unable to get db connection", err)
+	}
+	workers := worker.New()
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(sqlDB, cfg.MySQL.Database)
+	var repo repoInterface.Repository = instrumented.New(venueRepo.New(db, cfg), appMetrics)
+	// The cache sits outside the instrumentation, so the repository metrics
+	// only count the reads reaching the database.
+	appCache := newCache(cfg.Cache, appLog)
+	if appCache != nil {
+		repo = cached.New(repo, appCache, time.Duration(cfg.Cache.TTL), appMetrics)
+	}
+	usecase := module.NewTraced(module.New(repo, cfg, workers, appMetrics))
+	if len(args) > 0 {
+		var code int
+		switch args[0] {
+		case "migrate":
+			code = runMigrate(sqlDB, args[1:])
+		case "seed":
+			code = runSeed(sqlDB, args[1:])
+		case "import":
+			code = runImport(usecase, args[1:])
+		default:
+			fatal(appLog, "unknown command, expected migrate, seed or import", fmt.Errorf("unknown command %q", args[0]))
+		}
+		_ = shutdownTracing(context.Background())
+		os.Exit(code)
+	}
+	if cfg.AutoMigrate {
+		if err := autoMigrate(sqlDB); err != nil {
+			fatal(appLog, "unable to migrate db", err)
+		}
+	}
+	checker, err := newHealthChecker(sqlDB, workers)
+	if err != nil {
+		fatal(appLog, "unable to set up health checks", err)
+	}
+	hdlr := handler.New(usecase, checker, cfg.Pagination)
+	middlewareSvc := api.NewMiddlewareService(usecase, cfg, appLog, appMetrics)
+	router := gin.New()
+	router.Use(middlewareSvc.RequestID(), middlewareSvc.Locale(), middlewareSvc.Tracing(), middlewareSvc.AccessLog(), middlewareSvc.Metrics(), middlewareSvc.Recovery(), middlewareSvc.CORS())
+	registerRoutes(router, hdlr, middlewareSvc, cfg.HTTP)
+	// TestRoutesDocumented catches an undocumented route before a release,
+	// this only backs it up.
+	if err := hdlr.Spec().Check(router.Routes()); err != nil {
+		fatal(appLog, "every route must be documented in handler/openapi.go", err)
+	}
 
-	v1 := router.Group("/v1")
-	{
-		v1.GET("/city", hdlr.GetCities)
-		v1.POST("/register", hdlr.Register)
-		v1.POST("/login", hdlr.Login)
-		v1.GET("/venue", hdlr.GetVenues)
-		v1.GET("/nearby", hdlr.GetNearby)
-		v1.GET("/venue/:id", hdlr.GetVenueDetail)
-		v1.GET("/venue/package/:id", hdlr.GetPackageDetail)
-	}
-	usingAuth := router.Group("/v1")
-	usingAuth.Use(middlewareSvc.AuthenticateRequest())
-	{
-		usingAuth.POST("/venue/package/order", hdlr.CreateOrder)
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTP.Port),
+		Handler:           api.WithResponseController(router),
+		ReadTimeout:       time.Duration(cfg.HTTP.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.HTTP.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(cfg.HTTP.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.HTTP.IdleTimeout),
 	}
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", appMetrics.Handler())
+	metricsSrv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTP.MetricsPort),
+		Handler:           metricsMux,
+		ReadHeaderTimeout: time.Duration(cfg.HTTP.ReadHeaderTimeout),
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(appLog, "unable to serve http", err)
+		}
+	}()
+	go func() {
+		if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(appLog, "unable to serve metrics", err)
+		}
+	}()
 
-	router.Run(fmt.Sprintf(":%s", os.Getenv("GIN_PORT")))
+	<-ctx.Done()
+	stop()
+	checker.Drain()
+	appLog.Info("shutting down, failing readiness before draining requests", slog.Duration("delay", time.Duration(cfg.HTTP.ShutdownDelay)))
+	time.Sleep(time.Duration(cfg.HTTP.ShutdownDelay))
+	appLog.Info("shutting down, draining requests and background workers")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.HTTP.ShutdownTimeout))
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		appLog.Error("http server shutdown failed", slog.String("error", err.Error()))
+	}
+	// Shut down last so the metrics of the drained requests can still be
+	// scraped meanwhile.
+	if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
+		appLog.Error("metrics server shutdown failed", slog.String("error", err.Error()))
+	}
+	if err := workers.Shutdown(shutdownCtx); err != nil {
+		appLog.Error("background workers shutdown failed", slog.String("error", err.Error()))
+	}
+	if err := sqlDB.Close(); err != nil {
+		appLog.Error("close db failed", slog.String("error", err.Error()))
+	}
+	if r, ok := appCache.(*cache.Redis); ok {
+		_ = r.Close()
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		appLog.Error("flush traces failed", slog.String("error", err.Error()))
+	}
 }
 
-func conn() *gorm.DB {
-	defaultParams := "charset=utf8mb4&parseTime=True&loc=Local"
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s", os.Getenv("MYSQL_USER"), os.Getenv("MYSQL_PASSWORD"), os.Getenv("MYSQL_HOST"), os.Getenv("MYSQL_PORT"), os.Getenv("MYSQL_DATABASE"), defaultParams)
+// conn opens the database and applies the pool settings. While the database
+// is still starting up the connection is retried with exponential backoff
+// until the configured connect timeout.
+func conn(cfg *config.Config, appLog *slog.Logger) *gorm.DB {
+	sqlDB, err := sql.Open("mysql", cfg.MySQL.DSN())
+	if err != nil {
+		fatal(appLog, "unable to connect db", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MySQL.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MySQL.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.MySQL.ConnMaxLifetime))
+	sqlDB.SetConnMaxIdleTime(time.Duration(cfg.MySQL.ConnMaxIdleTime))
+
+	deadline := time.Now().Add(time.Duration(cfg.MySQL.ConnectTimeout))
+	backoff := 500 * time.Millisecond
+	for {
+		err := sqlDB.Ping()
+		if err == nil {
+			break
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			fatal(appLog, "unable to connect db", err)
+		}
+		appLog.Warn("db is not ready, retrying", slog.Duration("backoff", backoff), slog.String("error", err.Error()))
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.NewGorm(time.Duration(cfg.Log.SlowQuery)),
+	})
 	if err != nil {
-		log.Fatalf("Error when try to connect db: %v", err)
+		fatal(appLog, "unable to connect db", err)
 	}
 	return db
 }
+
+func fatal(appLog *slog.Logger, msg string, err error) {
+	appLog.Error(msg, slog.String("error", err.Error()))
+	os.Exit(1)
+}
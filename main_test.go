@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/faruqfadhil/venue-api/handler"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	"github.com/faruqfadhil/venue-api/pkg/config"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// TestRoutesDocumented checks every route served is documented in
+// handler/openapi.go and every documented one is served.
+func TestRoutesDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	appMetrics := metrics.New()
+	hdlr := handler.New(nil, nil, cfg.Pagination)
+	mw := api.NewMiddlewareService(nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)), appMetrics)
+
+	router := gin.New()
+	registerRoutes(router, hdlr, mw, cfg.HTTP)
+	if err := hdlr.Spec().Check(router.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	schema "github.com/faruqfadhil/venue-api/db"
+	"github.com/faruqfadhil/venue-api/pkg/migrate"
+)
+
+const (
+	migrationTable = "schema_migrations"
+	seedTable      = "schema_seeds"
+)
+
+func newMigrator(sqlDB *sql.DB) (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(schema.Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, migrationTable, migrations), nil
+}
+
+// runMigrate implements `venue-api migrate up|down|status [N]` and returns the
+// process exit code.
+func runMigrate(sqlDB *sql.DB, args []string) int {
+	usage := func() int {
+		fmt.Fprintln(os.Stderr, "usage: venue-api migrate up [N] | down [N] | status")
+		return 2
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return usage()
+	}
+	steps := 0
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return usage()
+		}
+		steps = n
+	}
+
+	m, err := newMigrator(sqlDB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load migrations: %v\n", err)
+		return 1
+	}
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx, steps)
+		for _, mig := range applied {
+			fmt.Printf("applied %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate up failed: %v\n", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migration")
+		}
+	case "down":
+		reverted, err := m.Down(ctx, steps)
+		for _, mig := range reverted {
+			fmt.Printf("reverted %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate down failed: %v\n", err)
+			return 1
+		}
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate status failed: %v\n", err)
+			return 1
+		}
+		for _, st := range statuses {
+			state := "pending"
+			if st.AppliedAt != nil {
+				state = "applied " + st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", st.Migration.Version, st.Migration.Name, state)
+		}
+	default:
+		return usage()
+	}
+	return 0
+}
+
+// runSeed implements `venue-api seed`, it loads the sample data files that
+// haven't been loaded yet.
+func runSeed(sqlDB *sql.DB, args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	admin := fs.String("admin", "", "also create an admin account with this email and a generated password, for development only")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: venue-api seed [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	seeds, err := migrate.Load(schema.Seeds, "seeds")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load seeds: %v\n", err)
+		return 1
+	}
+	applied, err := migrate.New(sqlDB, seedTable, seeds).Up(context.Background(), 0)
+	for _, seed := range applied {
+		fmt.Printf("seeded %04d_%s\n", seed.Version, seed.Name)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "seed failed: %v\n", err)
+		return 1
+	}
+	if len(applied) == 0 {
+		fmt.Println("no pending seed")
+	}
+	if *admin != "" {
+		return seedAdmin(sqlDB, *admin)
+	}
+	return 0
+}
+
+// seedAdmin creates an admin account and prints its password, which is not
+// shown again.
+func seedAdmin(sqlDB *sql.DB, email string) int {
+	password, err := generatePassword()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to generate a password: %v\n", err)
+		return 1
+	}
+	_, err = sqlDB.Exec("INSERT INTO auth (email, fullname, password, role) VALUES (?, ?, ?, ?)",
+		email, "Administrator", password, entity.RoleAdmin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create admin %s: %v\n", email, err)
+		return 1
+	}
+	fmt.Printf("created admin %s with password %s\n", email, password)
+	return 0
+}
+
+// generatePassword returns a random password meeting the registration policy,
+// letters and digits.
+func generatePassword() (string, error) {
+	b := make([]byte, 12)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		password := hex.EncodeToString(b)
+		if strings.ContainsAny(password, "0123456789") && strings.ContainsAny(password, "abcdef") {
+			return password, nil
+		}
+	}
+}
+
+// autoMigrate applies the pending migrations on startup.
+func autoMigrate(sqlDB *sql.DB) error {
+	m, err := newMigrator(sqlDB)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(context.Background(), 0)
+	return err
+}
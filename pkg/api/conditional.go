@@ -0,0 +1,63 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseCacheable answers like ResponseSuccess, tagged with an ETag of the
+// body and the Last-Modified date of the content when it is known. A client
+// whose copy is still current gets a 304 Not Modified without a body.
+func ResponseCacheable(c *gin.Context, out interface{}, meta *ResponseMeta, lastModified time.Time) {
+	body, err := json.Marshal(Response{
+		Data: out,
+		Meta: meta,
+	})
+	if err != nil {
+		ResponseFailed(c, errutil.New(errutil.ErrInternal, fmt.Errorf("[ResponseCacheable] err: %v", err)))
+		return
+	}
+	header := c.Writer.Header()
+	// The same content is sent in every locale, so the locale is part of the
+	// tag, see the Locale middleware.
+	sum := sha256.Sum256(append([]byte(header.Get("Content-Language")+"\n"), body...))
+	etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", "no-cache")
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// notModified evaluates the conditional headers of r as RFC 9110 does, an
+// If-None-Match header wins over If-Modified-Since.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	// HTTP dates have a one second precision.
+	return !lastModified.Truncate(time.Second).After(ims)
+}
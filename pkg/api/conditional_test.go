@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var lastModified = time.Date(2024, 3, 10, 12, 0, 0, 500_000_000, time.UTC)
+
+// serveCacheable goes through an engine, which writes the status of a
+// response without a body once the handler returns.
+func serveCacheable(locale string, headers map[string]string, modified time.Time) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/venues", func(c *gin.Context) {
+		if locale != "" {
+			c.Header("Content-Language", locale)
+		}
+		ResponseCacheable(c, map[string]string{"name": "Gedung"}, nil, modified)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/v1/venues", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestResponseCacheable(t *testing.T) {
+	first := serveCacheable("id", nil, lastModified)
+	if first.Code != http.StatusOK || first.Body.Len() == 0 {
+		t.Fatalf("status = %d with %d bytes, want 200 with a body", first.Code, first.Body.Len())
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+	if got := first.Header().Get("Last-Modified"); got != "Sun, 10 Mar 2024 12:00:00 GMT" {
+		t.Errorf("Last-Modified = %q", got)
+	}
+	if got := first.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if other := serveCacheable("en", nil, lastModified).Header().Get("ETag"); other == etag {
+		t.Error("the ETag does not depend on the locale")
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		modified time.Time
+		want     int
+	}{
+		{"matching etag", map[string]string{"If-None-Match": etag}, lastModified, http.StatusNotModified},
+		{"weak etag", map[string]string{"If-None-Match": "W/" + etag}, lastModified, http.StatusNotModified},
+		{"etag in a list", map[string]string{"If-None-Match": `"other", ` + etag}, lastModified, http.StatusNotModified},
+		{"any etag", map[string]string{"If-None-Match": "*"}, lastModified, http.StatusNotModified},
+		{"other etag", map[string]string{"If-None-Match": `"other"`}, lastModified, http.StatusOK},
+		{"modified since", map[string]string{"If-Modified-Since": "Sun, 10 Mar 2024 11:59:59 GMT"}, lastModified, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": "Sun, 10 Mar 2024 12:00:00 GMT"}, lastModified, http.StatusNotModified},
+		{"not modified since a later date", map[string]string{"If-Modified-Since": "Mon, 11 Mar 2024 00:00:00 GMT"}, lastModified, http.StatusNotModified},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, lastModified, http.StatusOK},
+		{"unknown last modified", map[string]string{"If-Modified-Since": "Mon, 11 Mar 2024 00:00:00 GMT"}, time.Time{}, http.StatusOK},
+		{"etag wins over the date", map[string]string{
+			"If-None-Match":     `"other"`,
+			"If-Modified-Since": "Mon, 11 Mar 2024 00:00:00 GMT",
+		}, lastModified, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveCacheable("id", tt.headers, tt.modified)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("ETag = %q, want %q", w.Header().Get("ETag"), etag)
+			}
+			if tt.want == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 with a %d bytes body", w.Body.Len())
+			}
+		})
+	}
+}
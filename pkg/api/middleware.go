@@ -2,21 +2,231 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
 	"strings"
+	"time"
 
+	"github.com/faruqfadhil/venue-api/core/entity"
 	"github.com/faruqfadhil/venue-api/core/module"
+	"github.com/faruqfadhil/venue-api/pkg/config"
 	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+	"github.com/faruqfadhil/venue-api/pkg/tracing"
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "requestID"
+	// maxRequestIDLength bounds the IDs accepted from clients or proxies so
+	// they cannot flood the logs.
+	maxRequestIDLength = 128
+)
+
+var quietRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+}
+
 type MiddlewareService struct {
 	authSvc module.Usecase
+	cfg     *config.Config
+	log     *slog.Logger
+	metrics *metrics.Metrics
 }
 
-func NewMiddlewareService(authSvc module.Usecase) *MiddlewareService {
+func NewMiddlewareService(authSvc module.Usecase, cfg *config.Config, log *slog.Logger, m *metrics.Metrics) *MiddlewareService {
 	return &MiddlewareService{
 		authSvc: authSvc,
+		cfg:     cfg,
+		log:     log,
+		metrics: m,
+	}
+}
+
+// RequestID reuses the X-Request-ID sent by the client or a proxy, or
+// generates one, echoes it in the response and tags the request logger with
+// it. It must come first so everything after it logs the ID.
+func (s *MiddlewareService) RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		ctx.Set(requestIDKey, id)
+		ctx.Header(requestIDHeader, id)
+
+		l := s.log.With(slog.String("request_id", id))
+		ctx.Request = ctx.Request.WithContext(logger.NewContext(ctx.Request.Context(), l))
+		ctx.Next()
+	}
+}
+
+// Locale negotiates the locale of the messages from the Accept-Language
+// header. AuthenticateRequest overrides it with the preference of the user,
+// if any.
+func (s *MiddlewareService) Locale() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Vary", "Accept-Language")
+		setLocale(ctx, i18n.Negotiate(ctx.GetHeader("Accept-Language")))
+		ctx.Next()
+	}
+}
+
+func setLocale(ctx *gin.Context, locale string) {
+	ctx.Header("Content-Language", locale)
+	ctx.Request = ctx.Request.WithContext(i18n.NewContext(ctx.Request.Context(), locale))
+}
+
+// AccessLog logs every request once it has been served.
+func (s *MiddlewareService) AccessLog() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		// Probes and scrapes hit the app every few seconds, they are only
+		// worth logging when they fail.
+		level := slog.LevelInfo
+		if quietRoutes[ctx.FullPath()] && ctx.Writer.Status() < 400 {
+			level = slog.LevelDebug
+		}
+		reqCtx := ctx.Request.Context()
+		logger.FromContext(reqCtx).Log(reqCtx, level, "request served",
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", ctx.FullPath()),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.Int("status", ctx.Writer.Status()),
+			slog.Int("bytes", ctx.Writer.Size()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ctx.ClientIP()),
+		)
+	}
+}
+
+// Tracing continues the trace sent in the traceparent header, or starts a new
+// one, with a server span per request and tags the request logger with the
+// trace ID. It must come after RequestID.
+func (s *MiddlewareService) Tracing() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		route := ctx.FullPath()
+		name := ctx.Request.Method + " " + route
+		if route == "" {
+			name = ctx.Request.Method
+		}
+		reqCtx, span := tracing.Tracer().Start(reqCtx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+				semconv.UserAgentOriginal(ctx.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+		if sc := span.SpanContext(); sc.IsValid() {
+			l := logger.FromContext(reqCtx).With(slog.String("trace_id", sc.TraceID().String()))
+			reqCtx = logger.NewContext(reqCtx, l)
+		}
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+	}
+}
+
+// Metrics counts the requests and their latency per route and status.
+// Requests matching no route share a single label to keep the cardinality
+// bounded.
+func (s *MiddlewareService) Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		s.metrics.ObserveRequest(ctx.Request.Method, route, ctx.Writer.Status(), time.Since(start))
+	}
+}
+
+// Recovery turns a panic into a 500 response and logs it with its stack.
+func (s *MiddlewareService) Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(ctx *gin.Context, rec interface{}) {
+		reqCtx := ctx.Request.Context()
+		logger.FromContext(reqCtx).ErrorContext(reqCtx, "panic recovered",
+			slog.Any("panic", rec),
+			slog.String("stack", string(debug.Stack())),
+		)
+		ResponseFailed(ctx, errutil.New(errutil.ErrInternal, fmt.Errorf("panic: %v", rec)))
+		ctx.Abort()
+	})
+}
+
+// CORS allows the configured origins to call the API with a bearer token.
+func (s *MiddlewareService) CORS() gin.HandlerFunc {
+	config := cors.DefaultConfig()
+	config.AllowHeaders = []string{"Authorization", "Accept-Language", "If-None-Match", "If-Modified-Since", requestIDHeader, "traceparent", "tracestate"}
+	config.ExposeHeaders = []string{requestIDHeader, "Content-Language", "ETag", "Last-Modified"}
+	config.AllowOrigins = s.cfg.HTTP.CORSAllowedOrigins
+	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	return cors.New(config)
+}
+
+// Timeout bounds the request context with the given deadline so the queries
+// of a slow or abandoned request are cancelled instead of piling up.
+func (s *MiddlewareService) Timeout(d time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), d)
+		defer cancel()
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+	}
+}
+
+type responseControllerKey struct{}
+
+// WithResponseController wraps the router so ReadTimeout can reach the
+// connection of a request, the gin writer doesn't unwrap to it.
+func WithResponseController(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), responseControllerKey{}, http.NewResponseController(w))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ReadTimeout replaces the read timeout of the server on a route whose
+// request bodies take longer to send, e.g. uploads.
+func (s *MiddlewareService) ReadTimeout(d time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rc, ok := ctx.Request.Context().Value(responseControllerKey{}).(*http.ResponseController)
+		if ok {
+			if err := rc.SetReadDeadline(time.Now().Add(d)); err != nil {
+				logger.FromContext(ctx.Request.Context()).WarnContext(ctx.Request.Context(), "unable to extend the read deadline", slog.String("error", err.Error()))
+			}
+		}
+		ctx.Next()
 	}
 }
 
@@ -24,30 +234,72 @@ func (s *MiddlewareService) AuthenticateRequest() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := ctx.GetHeader("Authorization")
 		if !strings.Contains(token, "Bearer") {
-			ResponseFailed(ctx, errutil.New(errutil.ErrUnauthorized, fmt.Errorf("invalid token"), "anda tidak diizinkan mengakses aplikasi ini"))
+			ResponseFailed(ctx, errutil.NewWithCode(errutil.ErrUnauthorized, entity.ErrCodeInvalidToken, fmt.Errorf("invalid token")))
 			ctx.Abort()
 			return
 		}
 		token = strings.Replace(token, "Bearer ", "", -1)
 
-		validate, err := s.authSvc.ValidateToken(context.Background(), token)
+		validate, err := s.authSvc.ValidateToken(ctx.Request.Context(), token)
 		if err != nil {
-			ResponseFailed(ctx, errutil.New(errutil.ErrUnauthorized, err, "anda tidak diizinkan mengakses aplikasi ini"))
+			ResponseFailed(ctx, errutil.NewWithCode(errutil.ErrUnauthorized, entity.ErrCodeInvalidToken, err))
 			ctx.Abort()
 			return
 		}
 		if validate == nil {
-			ResponseFailed(ctx, errutil.New(errutil.ErrUnauthorized, err, "anda tidak diizinkan mengakses aplikasi ini"))
+			ResponseFailed(ctx, errutil.NewWithCode(errutil.ErrUnauthorized, entity.ErrCodeInvalidToken, err))
 			ctx.Abort()
 			return
 		}
 
 		if validate != nil {
+			reqCtx := ctx.Request.Context()
+			l := logger.FromContext(reqCtx).With(slog.Int("user_id", validate.ID))
+			ctx.Request = ctx.Request.WithContext(logger.NewContext(reqCtx, l))
 			ctx.Set("id", validate.ID)
 			ctx.Set("email", validate.Email)
 			ctx.Set("fullname", validate.FullName)
+			ctx.Set("role", validate.Role)
+			ctx.Set("locale", validate.Locale)
+			if i18n.IsSupported(validate.Locale) {
+				setLocale(ctx, validate.Locale)
+			}
 			ctx.Next()
 			return
 		}
 	}
 }
+
+// AuthorizeRole only lets through requests of an authenticated user having
+// one of the given roles, it must be used after AuthenticateRequest.
+func (s *MiddlewareService) AuthorizeRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		role := ctx.GetString("role")
+		for _, r := range roles {
+			if r == role {
+				ctx.Next()
+				return
+			}
+		}
+		ResponseFailed(ctx, errutil.NewWithCode(errutil.ErrForbidden, entity.ErrCodeRoleNotAllowed, fmt.Errorf("role %q not allowed", role)))
+		ctx.Abort()
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
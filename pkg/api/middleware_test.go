@@ -0,0 +1,74 @@
+package api
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// slowBody sends its chunks one delay apart.
+type slowBody struct {
+	chunks []string
+	delay  time.Duration
+}
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	if len(b.chunks) == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(b.delay)
+	n := copy(p, b.chunks[0])
+	b.chunks = b.chunks[1:]
+	return n, nil
+}
+
+func TestReadTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mw := NewMiddlewareService(nil, &config.Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+	router := gin.New()
+	echo := func(c *gin.Context) {
+		b, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, string(b))
+	}
+	router.POST("/default", echo)
+	router.POST("/extended", mw.ReadTimeout(5*time.Second), echo)
+
+	srv := httptest.NewUnstartedServer(WithResponseController(router))
+	srv.Config.ReadTimeout = 200 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		wantOK bool
+	}{
+		{"/default", false},
+		{"/extended", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			body := &slowBody{chunks: []string{"a", "b", "c", "d"}, delay: 100 * time.Millisecond}
+			resp, err := http.Post(srv.URL+tt.path, "text/plain", body)
+			ok := false
+			if err == nil {
+				b, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				ok = resp.StatusCode == http.StatusOK && strings.TrimSpace(string(b)) == "abcd"
+			}
+			if ok != tt.wantOK {
+				t.Errorf("POST %s with a body sent over 400ms: ok = %v (err %v), want %v", tt.path, ok, err, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"net/http"
 
 	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,6 +25,11 @@ type ResponseMeta struct {
 	TotalPage    int    `json:"totalPage,omitempty"`
 	CurrentItems int    `json:"currentItems,omitempty"`
 	TotalItems   int    `json:"totalItems,omitempty"`
+	NextCursor   string `json:"nextCursor,omitempty"`
+	RequestID    string `json:"requestId,omitempty"`
+	// ErrorCode and Details are only set on error responses.
+	ErrorCode string           `json:"errorCode,omitempty"`
+	Details   []errutil.Detail `json:"details,omitempty"`
 }
 
 func ResponseSuccess(c *gin.Context, out interface{}, meta *ResponseMeta) {
@@ -30,57 +39,80 @@ func ResponseSuccess(c *gin.Context, out interface{}, meta *ResponseMeta) {
 	})
 }
 
+var statusByType = []struct {
+	typeErr error
+	status  int
+}{
+	{errutil.ErrGeneralBadRequest, http.StatusBadRequest},
+	{errutil.ErrGeneralNotFound, http.StatusNotFound},
+	{errutil.ErrUnauthorized, http.StatusUnauthorized},
+	{errutil.ErrForbidden, http.StatusForbidden},
+	{errutil.ErrConflict, http.StatusConflict},
+	{errutil.ErrTooManyRequests, http.StatusTooManyRequests},
+	{errutil.ErrUnprocessable, http.StatusUnprocessableEntity},
+}
+
 func ResponseFailed(c *gin.Context, err error) {
-	resp := internalServerErr(err)
+	status := http.StatusInternalServerError
 	typeErr := errutil.GetTypeErr(err)
-	if errors.Is(typeErr, errutil.ErrGeneralBadRequest) {
-		resp = badRequestErr(err)
-	}
-	if errors.Is(typeErr, errutil.ErrGeneralNotFound) {
-		resp = notFoundErr(err)
+	for _, st := range statusByType {
+		if errors.Is(typeErr, st.typeErr) {
+			status = st.status
+			break
+		}
 	}
-	if errors.Is(typeErr, errutil.ErrUnauthorized) {
-		resp = unauthorizedErr(err)
+	locale := i18n.FromContext(c.Request.Context())
+	resp := errorResponse(locale, status, err)
+	if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		resp = timeoutErr(locale)
 	}
+	resp.Meta.RequestID = RequestID(c)
+	logFailure(c, resp.Meta.Code, err)
 	c.JSON(resp.Meta.Code, resp)
 }
 
-func internalServerErr(err error) *Response {
-	return &Response{
-		Meta: &ResponseMeta{
-			Status:  "error",
-			Code:    http.StatusInternalServerError,
-			Message: err.Error(),
-		},
-	}
+// RequestID returns the ID assigned to the request by the RequestID
+// middleware.
+func RequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
 }
 
-func notFoundErr(err error) *Response {
-	return &Response{
-		Meta: &ResponseMeta{
-			Status:  "error",
-			Code:    http.StatusNotFound,
-			Message: err.Error(),
-		},
+// logFailure records the cause of a failed response, which is never shown to
+// the client, server errors as errors and client ones as warnings.
+func logFailure(c *gin.Context, code int, err error) {
+	ctx := c.Request.Context()
+	level := slog.LevelWarn
+	if code >= http.StatusInternalServerError {
+		level = slog.LevelError
 	}
+	logger.FromContext(ctx).Log(ctx, level, "request failed",
+		slog.String("method", c.Request.Method),
+		slog.String("route", c.FullPath()),
+		slog.Int("status", code),
+		slog.String("error_code", errutil.GetCode(err)),
+		slog.String("error", errutil.GetOriginalErr(err).Error()),
+	)
 }
 
-func unauthorizedErr(err error) *Response {
+func errorResponse(locale string, status int, err error) *Response {
 	return &Response{
 		Meta: &ResponseMeta{
-			Status:  "error",
-			Code:    http.StatusUnauthorized,
-			Message: err.Error(),
+			Status:    "error",
+			Code:      status,
+			Message:   i18n.Error(locale, err),
+			ErrorCode: errutil.GetCode(err),
+			Details:   i18n.Details(locale, err),
 		},
 	}
 }
 
-func badRequestErr(err error) *Response {
+func timeoutErr(locale string) *Response {
 	return &Response{
 		Meta: &ResponseMeta{
-			Status:  "error",
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
+			Status:    "error",
+			Code:      http.StatusGatewayTimeout,
+			Message:   i18n.T(locale, errutil.CodeTimeout),
+			ErrorCode: errutil.CodeTimeout,
 		},
 	}
 }
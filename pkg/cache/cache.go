@@ -0,0 +1,15 @@
+// Package cache keeps byte values for a while, in the process memory or in a
+// Redis compatible server shared by the instances of the app.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores values by key. A missing or expired key is not an error.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl, forever when ttl is zero.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is a Cache in the process memory evicting the least recently used
+// keys beyond its size.
+type Memory struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+type memoryItem struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemory returns a cache holding at most size keys.
+func NewMemory(size int) *Memory {
+	return &Memory{
+		size:  size,
+		order: list.New(),
+		items: map[string]*list.Element{},
+		now:   time.Now,
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.get(key)
+	return value, ok, nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(key, value, ttl)
+	return nil
+}
+
+// get returns the value of key and marks it as recently used, m.mu must be
+// held.
+func (m *Memory) get(key string) ([]byte, bool) {
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := el.Value.(*memoryItem)
+	if !item.expires.IsZero() && !m.now().Before(item.expires) {
+		m.remove(el)
+		return nil, false
+	}
+	m.order.MoveToFront(el)
+	return item.value, true
+}
+
+// set stores value under key and evicts the least recently used keys beyond
+// the size, m.mu must be held.
+func (m *Memory) set(key string, value []byte, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = m.now().Add(ttl)
+	}
+	if el, ok := m.items[key]; ok {
+		item := el.Value.(*memoryItem)
+		item.value, item.expires = value, expires
+		m.order.MoveToFront(el)
+		return
+	}
+	m.items[key] = m.order.PushFront(&memoryItem{key: key, value: value, expires: expires})
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.items, el.Value.(*memoryItem).key)
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(3)
+	for _, key := range []string{"a", "b", "c"} {
+		if err := m.Set(ctx, key, []byte(key), 0); err != nil {
+			t.Fatalf("Set %s: %v", key, err)
+		}
+	}
+	// Reading a and writing b again leaves c as the least recently used.
+	if _, ok, _ := m.Get(ctx, "a"); !ok {
+		t.Fatal("a missing")
+	}
+	if err := m.Set(ctx, "b", []byte("b2"), 0); err != nil {
+		t.Fatalf("Set b: %v", err)
+	}
+	if err := m.Set(ctx, "d", []byte("d"), 0); err != nil {
+		t.Fatalf("Set d: %v", err)
+	}
+	if err := m.Set(ctx, "e", []byte("e"), 0); err != nil {
+		t.Fatalf("Set e: %v", err)
+	}
+
+	tests := []struct {
+		key   string
+		want  string
+		found bool
+	}{
+		{"c", "", false},
+		{"a", "", false},
+		{"b", "b2", true},
+		{"d", "d", true},
+		{"e", "e", true},
+	}
+	for _, tt := range tests {
+		value, ok, err := m.Get(ctx, tt.key)
+		if err != nil || ok != tt.found || string(value) != tt.want {
+			t.Errorf("Get(%s) = %q, %v, %v, want %q, %v", tt.key, value, ok, err, tt.want, tt.found)
+		}
+	}
+	if m.order.Len() != 3 || len(m.items) != 3 {
+		t.Errorf("holds %d keys (%d in the map), want 3", m.order.Len(), len(m.items))
+	}
+}
+
+func TestMemoryTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := NewMemory(10)
+	m.now = func() time.Time { return now }
+
+	if err := m.Set(ctx, "short", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := m.Set(ctx, "forever", []byte("2"), 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		key     string
+		found   bool
+	}{
+		{"before the ttl", 59 * time.Second, "short", true},
+		{"at the ttl", time.Minute, "short", false},
+		{"without ttl", 24 * time.Hour, "forever", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.now = func() time.Time { return now.Add(tt.elapsed) }
+			if _, ok, _ := m.Get(ctx, tt.key); ok != tt.found {
+				t.Errorf("Get(%s) after %v found = %v, want %v", tt.key, tt.elapsed, ok, tt.found)
+			}
+		})
+	}
+	if _, ok := m.items["short"]; ok {
+		t.Error("the expired key is still held")
+	}
+
+	// Writing a key again restarts its ttl.
+	m.now = func() time.Time { return now }
+	_ = m.Set(ctx, "short", []byte("1"), time.Minute)
+	m.now = func() time.Time { return now.Add(50 * time.Second) }
+	_ = m.Set(ctx, "short", []byte("1"), time.Minute)
+	m.now = func() time.Time { return now.Add(100 * time.Second) }
+	if _, ok, _ := m.Get(ctx, "short"); !ok {
+		t.Error("the rewritten key expired with its first ttl")
+	}
+}
@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Redis is a Cache kept in a server speaking the Redis protocol (RESP), e.g.
+// Redis, Valkey or KeyDB. Only the few commands the cache needs are
+// implemented.
+type Redis struct {
+	addr     string
+	password string
+	db       int
+	timeout  time.Duration
+	// pool holds the idle connections.
+	pool chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// redisError is an error answered by the server, the connection is still
+// usable.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// NewRedis returns a cache on the server at addr keeping at most poolSize
+// idle connections. Each command is given timeout unless the context ends
+// sooner. No connection is made before the first command.
+func NewRedis(addr, password string, db, poolSize int, timeout time.Duration) *Redis {
+	return &Redis{
+		addr:     addr,
+		password: password,
+		db:       db,
+		timeout:  timeout,
+		pool:     make(chan *redisConn, poolSize),
+	}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []interface{}{"SET", key, value}
+	if ttl > 0 {
+		// PX 0 is rejected, a ttl under a millisecond is rounded up.
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+// Ping checks that the server answers, it is meant for readiness checks.
+func (r *Redis) Ping(ctx context.Context) error {
+	_, err := r.do(ctx, "PING")
+	return err
+}
+
+// do sends a command and reads its reply: nil, a string, an int64, []byte or
+// a []interface{} of those.
+func (r *Redis) do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	conn, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.do(r.deadline(ctx), args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection is in an unknown state once a write or a read
+		// failed.
+		conn.Close()
+		return nil, err
+	}
+	r.release(conn)
+	return reply, err
+}
+
+func (r *Redis) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(r.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		return d
+	}
+	return deadline
+}
+
+// conn takes an idle connection or dials a new one, authenticated and on the
+// configured database.
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.pool:
+		return c, nil
+	default:
+	}
+	d := net.Dialer{Deadline: r.deadline(ctx)}
+	nc, err := d.DialContext(ctx, "tcp", r.addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis: dial %s: %v", r.addr, err)
+	}
+	c := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+	if r.password != "" {
+		if _, err := c.do(r.deadline(ctx), "AUTH", r.password); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, err := c.do(r.deadline(ctx), "SELECT", strconv.Itoa(r.db)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (r *Redis) release(c *redisConn) {
+	select {
+	case r.pool <- c:
+	default:
+		c.Close()
+	}
+}
+
+// Close closes the idle connections.
+func (r *Redis) Close() error {
+	for {
+		select {
+		case c := <-r.pool:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *redisConn) do(deadline time.Time, args ...interface{}) (interface{}, error) {
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if _, err := c.Write(encodeCommand(args)); err != nil {
+		return nil, fmt.Errorf("redis: write: %v", err)
+	}
+	return c.readReply()
+}
+
+// encodeCommand writes args, strings or []byte, as a RESP array of bulk
+// strings.
+func encodeCommand(args []interface{}) []byte {
+	b := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, a := range args {
+		var s []byte
+		switch v := a.(type) {
+		case string:
+			s = []byte(v)
+		case []byte:
+			s = v
+		default:
+			s = []byte(fmt.Sprint(v))
+		}
+		b = append(b, "$"+strconv.Itoa(len(s))+"\r\n"...)
+		b = append(b, s...)
+		b = append(b, "\r\n"...)
+	}
+	return b
+}
+
+func (c *redisConn) readReply() (interface{}, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		n, err := strconv.ParseInt(string(line[1:]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid integer reply: %v", err)
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length: %v", err)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		b := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, b); err != nil {
+			return nil, fmt.Errorf("redis: read: %v", err)
+		}
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length: %v", err)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		out := make([]interface{}, n)
+		for i := range out {
+			if out[i], err = c.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
+
+// readLine reads a line without its CRLF.
+func (c *redisConn) readLine() ([]byte, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("redis: read: %v", err)
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errors.New("redis: malformed reply line")
+	}
+	return line[:len(line)-2], nil
+}
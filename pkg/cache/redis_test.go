@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// respServer is a stand-in for a Redis server speaking enough RESP for the
+// commands the cache sends. The key "wrongtype" answers an error reply.
+type respServer struct {
+	ln       net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string][]byte
+	commands [][]string
+	dials    int
+}
+
+func newRESPServer(t *testing.T, password string) *respServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &respServer{ln: ln, password: password, data: map[string][]byte{}}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *respServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *respServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.dials++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *respServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed := s.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, args)
+		reply := s.reply(args, &authed)
+		s.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *respServer) reply(args []string, authed *bool) string {
+	cmd := strings.ToUpper(args[0])
+	if cmd == "AUTH" {
+		if len(args) == 2 && args[1] == s.password {
+			*authed = true
+			return "+OK\r\n"
+		}
+		return "-WRONGPASS invalid username-password pair\r\n"
+	}
+	if !*authed {
+		return "-NOAUTH Authentication required.\r\n"
+	}
+	switch cmd {
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		if args[1] == "wrongtype" {
+			return "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"
+		}
+		value, ok := s.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.data[args[1]] = []byte(args[2])
+		return "+OK\r\n"
+	}
+	return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+}
+
+// readCommand reads a RESP array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, fmt.Errorf("unexpected argument %q", line)
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func (s *respServer) Commands() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]string(nil), s.commands...)
+}
+
+func (s *respServer) Dials() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dials
+}
+
+func TestRedisGetSet(t *testing.T) {
+	ctx := context.Background()
+	s := newRESPServer(t, "")
+	r := NewRedis(s.Addr(), "", 0, 2, time.Second)
+	defer r.Close()
+
+	if value, ok, err := r.Get(ctx, "missing"); err != nil || ok || value != nil {
+		t.Fatalf("Get(missing) = %q, %v, %v, want a miss", value, ok, err)
+	}
+	// Values are binary safe, a CRLF inside does not end the bulk string.
+	want := []byte("{\"a\":1}\r\n\x00\xff")
+	if err := r.Set(ctx, "key", want, 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	value, ok, err := r.Get(ctx, "key")
+	if err != nil || !ok || string(value) != string(want) {
+		t.Fatalf("Get(key) = %q, %v, %v, want %q", value, ok, err, want)
+	}
+	if err := r.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if got := s.Dials(); got != 1 {
+		t.Errorf("dialed %d times, want the connection reused", got)
+	}
+}
+
+func TestRedisSetTTL(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want []string
+	}{
+		{"without ttl", 0, []string{"SET", "key", "v"}},
+		{"in milliseconds", 1500 * time.Millisecond, []string{"SET", "key", "v", "PX", "1500"}},
+		{"in minutes", 5 * time.Minute, []string{"SET", "key", "v", "PX", "300000"}},
+		{"under a millisecond", 300 * time.Microsecond, []string{"SET", "key", "v", "PX", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRESPServer(t, "")
+			r := NewRedis(s.Addr(), "", 0, 1, time.Second)
+			defer r.Close()
+			if err := r.Set(ctx, "key", []byte("v"), tt.ttl); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			commands := s.Commands()
+			if len(commands) != 1 || strings.Join(commands[0], " ") != strings.Join(tt.want, " ") {
+				t.Errorf("sent %q, want %q", commands, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedisErrorReplyKeepsConnection(t *testing.T) {
+	ctx := context.Background()
+	s := newRESPServer(t, "")
+	r := NewRedis(s.Addr(), "", 0, 1, time.Second)
+	defer r.Close()
+
+	_, _, err := r.Get(ctx, "wrongtype")
+	if err == nil || !strings.HasPrefix(err.Error(), "redis: WRONGTYPE") {
+		t.Fatalf("Get(wrongtype) err = %v, want the WRONGTYPE reply", err)
+	}
+	if _, ok, err := r.Get(ctx, "missing"); err != nil || ok {
+		t.Fatalf("Get(missing) after an error reply = %v, %v", ok, err)
+	}
+	if got := s.Dials(); got != 1 {
+		t.Errorf("dialed %d times, want the connection kept after an error reply", got)
+	}
+}
+
+func TestRedisDial(t *testing.T) {
+	ctx := context.Background()
+	s := newRESPServer(t, "secret")
+	r := NewRedis(s.Addr(), "secret", 2, 1, time.Second)
+	defer r.Close()
+
+	if err := r.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if err := r.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	want := "AUTH secret|SELECT 2|PING|PING"
+	got := []string{}
+	for _, c := range s.Commands() {
+		got = append(got, strings.Join(c, " "))
+	}
+	if strings.Join(got, "|") != want {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+}
+
+func TestRedisDialWrongPassword(t *testing.T) {
+	ctx := context.Background()
+	s := newRESPServer(t, "secret")
+	r := NewRedis(s.Addr(), "wrong", 0, 1, time.Second)
+	defer r.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := r.Ping(ctx); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+			t.Fatalf("Ping err = %v, want the WRONGPASS reply", err)
+		}
+	}
+	// A connection that failed to authenticate is not pooled.
+	if got := s.Dials(); got != 2 {
+		t.Errorf("dialed %d times, want 2", got)
+	}
+}
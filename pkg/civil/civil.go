@@ -0,0 +1,63 @@
+// Package civil handles calendar dates that are independent of any time
+// zone, such as the day a venue is booked. A civil date is represented as a
+// time.Time at midnight UTC so it round-trips unchanged through a MySQL DATE
+// column and JSON.
+package civil
+
+import (
+	"time"
+)
+
+const (
+	DefaultTimezone = "Asia/Jakarta"
+	Layout          = "2006-01-02"
+)
+
+// wib is used when the tz database isn't available on the host.
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// now is replaced in tests.
+var now = time.Now
+
+// LoadLocation returns the IANA location by name, falling back to the
+// default timezone when the name is empty or unknown.
+func LoadLocation(name string) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(DefaultTimezone); err == nil {
+		return loc
+	}
+	return wib
+}
+
+// IsValidTimezone reports whether name is a known IANA timezone.
+func IsValidTimezone(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// Date returns the civil date of t as seen in t's own location.
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Today returns the current civil date in loc.
+func Today(loc *time.Location) time.Time {
+	return Date(now().In(loc))
+}
+
+// Parse parses a YYYY-MM-DD string into a civil date.
+func Parse(s string) (time.Time, error) {
+	return time.Parse(Layout, s)
+}
+
+// DaysBetween returns the number of days from a to b, both civil dates.
+func DaysBetween(a, b time.Time) int {
+	return int(Date(b).Sub(Date(a)).Hours() / 24)
+}
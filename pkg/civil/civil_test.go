@@ -0,0 +1,132 @@
+package civil
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDate(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	tests := []struct {
+		name string
+		in   time.Time
+		want time.Time
+	}{
+		{"midnight utc", day(2024, 3, 10), day(2024, 3, 10)},
+		{"utc afternoon", time.Date(2024, 3, 10, 16, 30, 0, 0, time.UTC), day(2024, 3, 10)},
+		{"23:30 wib", time.Date(2024, 3, 10, 23, 30, 0, 0, jakarta), day(2024, 3, 10)},
+		{"00:30 wib", time.Date(2024, 3, 11, 0, 30, 0, 0, jakarta), day(2024, 3, 11)},
+		{"00:30 wib seen in utc", time.Date(2024, 3, 11, 0, 30, 0, 0, jakarta).UTC(), day(2024, 3, 10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Date(tt.in)
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("Date(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToday(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	makassar := mustLoad(t, "Asia/Makassar")
+	tests := []struct {
+		name string
+		now  time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		// 23:30 WIB is 16:30 UTC, the same day everywhere.
+		{"23:30 wib in jakarta", time.Date(2024, 3, 10, 16, 30, 0, 0, time.UTC), jakarta, day(2024, 3, 10)},
+		{"23:30 wib in utc", time.Date(2024, 3, 10, 16, 30, 0, 0, time.UTC), time.UTC, day(2024, 3, 10)},
+		// 00:30 WIB is still the previous day in UTC.
+		{"00:30 wib in jakarta", time.Date(2024, 3, 10, 17, 30, 0, 0, time.UTC), jakarta, day(2024, 3, 11)},
+		{"00:30 wib in utc", time.Date(2024, 3, 10, 17, 30, 0, 0, time.UTC), time.UTC, day(2024, 3, 10)},
+		// 23:30 WIB is already 00:30 WITA the next day.
+		{"23:30 wib in makassar", time.Date(2024, 3, 10, 16, 30, 0, 0, time.UTC), makassar, day(2024, 3, 11)},
+		{"22:30 wib in makassar", time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC), makassar, day(2024, 3, 10)},
+		{"fixed wib zone", time.Date(2024, 3, 10, 17, 30, 0, 0, time.UTC), wib, day(2024, 3, 11)},
+	}
+	defer func(orig func() time.Time) { now = orig }(now)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = func() time.Time { return tt.now }
+			if got := Today(tt.loc); !got.Equal(tt.want) {
+				t.Errorf("Today(%s) at %v = %v, want %v", tt.loc, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	tests := []struct {
+		name string
+		a, b time.Time
+		want int
+	}{
+		{"same day", day(2024, 3, 10), day(2024, 3, 10), 0},
+		{"next day", day(2024, 3, 10), day(2024, 3, 11), 1},
+		{"past day", day(2024, 3, 10), day(2024, 3, 9), -1},
+		{"across a leap day", day(2024, 2, 28), day(2024, 3, 1), 2},
+		{"across a year", day(2023, 12, 31), day(2024, 12, 31), 366},
+		{"times of day are ignored", time.Date(2024, 3, 10, 23, 30, 0, 0, jakarta), time.Date(2024, 3, 11, 0, 30, 0, 0, jakarta), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysBetween(tt.a, tt.b); got != tt.want {
+				t.Errorf("DaysBetween(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"known", "Asia/Makassar", "Asia/Makassar"},
+		{"empty falls back", "", DefaultTimezone},
+		{"unknown falls back", "Mars/Olympus_Mons", DefaultTimezone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoadLocation(tt.in).String(); got != tt.want {
+				t.Errorf("LoadLocation(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse("2024-03-10")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !got.Equal(day(2024, 3, 10)) || got.Location() != time.UTC {
+		t.Errorf("Parse = %v, want %v", got, day(2024, 3, 10))
+	}
+	if got.Format(Layout) != "2024-03-10" {
+		t.Errorf("Format = %s", got.Format(Layout))
+	}
+	if _, err := Parse("10/03/2024"); err == nil {
+		t.Error("Parse accepted a non ISO date")
+	}
+}
@@ -0,0 +1,533 @@
+// Package config loads the application settings. Values come from, in
+// increasing precedence, the defaults, an optional YAML or TOML file, the
+// environment (a .env file included) and command line flags. Any environment
+// variable can instead be read from the file named by <NAME>_FILE, which is
+// how Docker secrets are mounted.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	toml "github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v2"
+)
+
+const minJWTSecretLength = 16
+
+type Config struct {
+	HTTP        HTTP       `yaml:"http" toml:"http"`
+	MySQL       MySQL      `yaml:"mysql" toml:"mysql"`
+	Auth        Auth       `yaml:"auth" toml:"auth"`
+	Pagination  Pagination `yaml:"pagination" toml:"pagination"`
+	Log         Log        `yaml:"log" toml:"log"`
+	Tracing     Tracing    `yaml:"tracing" toml:"tracing"`
+	Cache       Cache      `yaml:"cache" toml:"cache"`
+	Order       Order      `yaml:"order" toml:"order"`
+	AutoMigrate bool       `yaml:"autoMigrate" toml:"autoMigrate"`
+}
+
+type HTTP struct {
+	Port               int      `yaml:"port" toml:"port"`
+	CORSAllowedOrigins []string `yaml:"corsAllowedOrigins" toml:"corsAllowedOrigins"`
+	ReadTimeout        Duration `yaml:"readTimeout" toml:"readTimeout"`
+	ReadHeaderTimeout  Duration `yaml:"readHeaderTimeout" toml:"readHeaderTimeout"`
+	WriteTimeout       Duration `yaml:"writeTimeout" toml:"writeTimeout"`
+	IdleTimeout        Duration `yaml:"idleTimeout" toml:"idleTimeout"`
+	// RequestTimeout is the deadline of the request context, cancelling the
+	// queries of a request once reached. ExportTimeout replaces it on the
+	// report export and bulk import routes, and ReadTimeout on the import
+	// uploads.
+	RequestTimeout Duration `yaml:"requestTimeout" toml:"requestTimeout"`
+	ExportTimeout  Duration `yaml:"exportTimeout" toml:"exportTimeout"`
+	// ShutdownDelay is how long /readyz fails before the server stops
+	// accepting connections, to let load balancers take it out of rotation.
+	ShutdownDelay Duration `yaml:"shutdownDelay" toml:"shutdownDelay"`
+	// ShutdownTimeout bounds how long in-flight requests and background
+	// workers are waited for on SIGTERM.
+	ShutdownTimeout Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout"`
+	// MetricsPort serves the Prometheus metrics apart from the API, so they
+	// are only reachable where that port is.
+	MetricsPort int `yaml:"metricsPort" toml:"metricsPort"`
+}
+
+type MySQL struct {
+	Host            string   `yaml:"host" toml:"host"`
+	Port            int      `yaml:"port" toml:"port"`
+	User            string   `yaml:"user" toml:"user"`
+	Password        string   `yaml:"password" toml:"password"`
+	Database        string   `yaml:"database" toml:"database"`
+	MaxOpenConns    int      `yaml:"maxOpenConns" toml:"maxOpenConns"`
+	MaxIdleConns    int      `yaml:"maxIdleConns" toml:"maxIdleConns"`
+	ConnMaxLifetime Duration `yaml:"connMaxLifetime" toml:"connMaxLifetime"`
+	ConnMaxIdleTime Duration `yaml:"connMaxIdleTime" toml:"connMaxIdleTime"`
+	// ConnectTimeout is how long startup keeps retrying to reach the
+	// database before giving up.
+	ConnectTimeout Duration `yaml:"connectTimeout" toml:"connectTimeout"`
+}
+
+// DSN keeps both the driver and the MySQL session in UTC so DATE columns scan
+// back as civil dates and timestamps aren't shifted by the host zone.
+func (m MySQL) DSN() string {
+	params := "charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%27%2B00%3A00%27"
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?%s", m.User, m.Password, m.Host, m.Port, m.Database, params)
+}
+
+type Auth struct {
+	JWTSecret string   `yaml:"jwtSecret" toml:"jwtSecret"`
+	TokenTTL  Duration `yaml:"tokenTTL" toml:"tokenTTL"`
+}
+
+type Pagination struct {
+	DefaultPageSize int `yaml:"defaultPageSize" toml:"defaultPageSize"`
+	MaxPageSize     int `yaml:"maxPageSize" toml:"maxPageSize"`
+}
+
+type Log struct {
+	Level  string `yaml:"level" toml:"level"`
+	Format string `yaml:"format" toml:"format"`
+	// SlowQuery is the duration above which a query is logged as slow.
+	SlowQuery Duration `yaml:"slowQuery" toml:"slowQuery"`
+}
+
+type Tracing struct {
+	// Exporter is where spans are sent: none, otlp or stdout.
+	Exporter string `yaml:"exporter" toml:"exporter"`
+	// Endpoint is the host:port of the OTLP/HTTP collector.
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint"`
+	Insecure    bool    `yaml:"insecure" toml:"insecure"`
+	SampleRatio float64 `yaml:"sampleRatio" toml:"sampleRatio"`
+	ServiceName string  `yaml:"serviceName" toml:"serviceName"`
+}
+
+type Cache struct {
+	// Driver is where the reference data read from the database is cached:
+	// memory, redis or none.
+	Driver string   `yaml:"driver" toml:"driver"`
+	TTL    Duration `yaml:"ttl" toml:"ttl"`
+	// Size is the number of entries the memory driver keeps.
+	Size  int   `yaml:"size" toml:"size"`
+	Redis Redis `yaml:"redis" toml:"redis"`
+}
+
+type Redis struct {
+	Addr     string   `yaml:"addr" toml:"addr"`
+	Password string   `yaml:"password" toml:"password"`
+	DB       int      `yaml:"db" toml:"db"`
+	PoolSize int      `yaml:"poolSize" toml:"poolSize"`
+	Timeout  Duration `yaml:"timeout" toml:"timeout"`
+}
+
+type Order struct {
+	// TaxRate is the VAT (PPN) charged on top of the discounted price of an
+	// order, e.g. 0.11. Orders are not taxed by default.
+	TaxRate float64 `yaml:"taxRate" toml:"taxRate"`
+}
+
+// Limit returns the page size to use for a requested limit, the default when
+// none is requested and never more than the maximum.
+func (p Pagination) Limit(limit int) int {
+	if limit <= 0 {
+		return p.DefaultPageSize
+	}
+	if limit > p.MaxPageSize {
+		return p.MaxPageSize
+	}
+	return limit
+}
+
+// Duration is a time.Duration written as "24h" or "90m" in config files.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+func Default() *Config {
+	return &Config{
+		HTTP: HTTP{
+			Port:               8081,
+			MetricsPort:        9090,
+			CORSAllowedOrigins: []string{"http://localhost:3000"},
+			ReadTimeout:        Duration(15 * time.Second),
+			ReadHeaderTimeout:  Duration(5 * time.Second),
+			WriteTimeout:       Duration(5 * time.Minute),
+			IdleTimeout:        Duration(120 * time.Second),
+			RequestTimeout:     Duration(15 * time.Second),
+			ExportTimeout:      Duration(5 * time.Minute),
+			ShutdownDelay:      Duration(5 * time.Second),
+			ShutdownTimeout:    Duration(30 * time.Second),
+		},
+		MySQL: MySQL{
+			Port:            3306,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+			ConnectTimeout:  Duration(2 * time.Minute),
+		},
+		Auth: Auth{
+			TokenTTL: Duration(24 * time.Hour),
+		},
+		Pagination: Pagination{
+			DefaultPageSize: 10,
+			MaxPageSize:     100,
+		},
+		Log: Log{
+			Level:     "info",
+			Format:    "json",
+			SlowQuery: Duration(200 * time.Millisecond),
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "venue-api",
+		},
+		Cache: Cache{
+			Driver: "memory",
+			TTL:    Duration(5 * time.Minute),
+			Size:   10000,
+			Redis: Redis{
+				Addr:     "localhost:6379",
+				PoolSize: 10,
+				Timeout:  Duration(200 * time.Millisecond),
+			},
+		},
+	}
+}
+
+// field binds one setting to its environment variable and flag.
+type field struct {
+	name  string
+	env   string
+	flag  string
+	usage string
+	set   func(c *Config, v string) error
+}
+
+var fields = []*field{
+	{"http.port", "GIN_PORT", "port", "HTTP listen port", setInt(func(c *Config) *int { return &c.HTTP.Port })},
+	{"http.metricsPort", "METRICS_PORT", "metrics-port", "listen port of the Prometheus metrics", setInt(func(c *Config) *int { return &c.HTTP.MetricsPort })},
+	{"http.corsAllowedOrigins", "CORS_ALLOWED_ORIGINS", "cors-allowed-origins", "comma separated origins allowed by CORS", func(c *Config, v string) error {
+		c.HTTP.CORSAllowedOrigins = splitList(v)
+		return nil
+	}},
+	{"http.readTimeout", "HTTP_READ_TIMEOUT", "http-read-timeout", "maximum duration for reading a request", setDuration(func(c *Config) *Duration { return &c.HTTP.ReadTimeout })},
+	{"http.readHeaderTimeout", "HTTP_READ_HEADER_TIMEOUT", "http-read-header-timeout", "maximum duration for reading request headers", setDuration(func(c *Config) *Duration { return &c.HTTP.ReadHeaderTimeout })},
+	{"http.writeTimeout", "HTTP_WRITE_TIMEOUT", "http-write-timeout", "maximum duration for writing a response", setDuration(func(c *Config) *Duration { return &c.HTTP.WriteTimeout })},
+	{"http.idleTimeout", "HTTP_IDLE_TIMEOUT", "http-idle-timeout", "how long idle keep-alive connections are kept", setDuration(func(c *Config) *Duration { return &c.HTTP.IdleTimeout })},
+	{"http.requestTimeout", "HTTP_REQUEST_TIMEOUT", "http-request-timeout", "deadline of a request before its queries are cancelled", setDuration(func(c *Config) *Duration { return &c.HTTP.RequestTimeout })},
+	{"http.exportTimeout", "HTTP_EXPORT_TIMEOUT", "http-export-timeout", "deadline of report export and bulk import requests", setDuration(func(c *Config) *Duration { return &c.HTTP.ExportTimeout })},
+	{"http.shutdownDelay", "HTTP_SHUTDOWN_DELAY", "http-shutdown-delay", "how long readiness fails before the server stops on shutdown", setDuration(func(c *Config) *Duration { return &c.HTTP.ShutdownDelay })},
+	{"http.shutdownTimeout", "HTTP_SHUTDOWN_TIMEOUT", "http-shutdown-timeout", "how long to drain requests and workers on shutdown", setDuration(func(c *Config) *Duration { return &c.HTTP.ShutdownTimeout })},
+	{"mysql.host", "MYSQL_HOST", "mysql-host", "MySQL host", setString(func(c *Config) *string { return &c.MySQL.Host })},
+	{"mysql.port", "MYSQL_PORT", "mysql-port", "MySQL port", setInt(func(c *Config) *int { return &c.MySQL.Port })},
+	{"mysql.user", "MYSQL_USER", "mysql-user", "MySQL user", setString(func(c *Config) *string { return &c.MySQL.User })},
+	{"mysql.password", "MYSQL_PASSWORD", "mysql-password", "MySQL password", setString(func(c *Config) *string { return &c.MySQL.Password })},
+	{"mysql.database", "MYSQL_DATABASE", "mysql-database", "MySQL database name", setString(func(c *Config) *string { return &c.MySQL.Database })},
+	{"mysql.maxOpenConns", "MYSQL_MAX_OPEN_CONNS", "mysql-max-open-conns", "maximum open connections, 0 is unlimited", setInt(func(c *Config) *int { return &c.MySQL.MaxOpenConns })},
+	{"mysql.maxIdleConns", "MYSQL_MAX_IDLE_CONNS", "mysql-max-idle-conns", "maximum idle connections", setInt(func(c *Config) *int { return &c.MySQL.MaxIdleConns })},
+	{"mysql.connMaxLifetime", "MYSQL_CONN_MAX_LIFETIME", "mysql-conn-max-lifetime", "maximum lifetime of a connection", setDuration(func(c *Config) *Duration { return &c.MySQL.ConnMaxLifetime })},
+	{"mysql.connMaxIdleTime", "MYSQL_CONN_MAX_IDLE_TIME", "mysql-conn-max-idle-time", "maximum idle time of a connection", setDuration(func(c *Config) *Duration { return &c.MySQL.ConnMaxIdleTime })},
+	{"mysql.connectTimeout", "MYSQL_CONNECT_TIMEOUT", "mysql-connect-timeout", "how long to retry connecting on startup", setDuration(func(c *Config) *Duration { return &c.MySQL.ConnectTimeout })},
+	{"auth.jwtSecret", "JWT_SECRET", "jwt-secret", "secret signing the access tokens", setString(func(c *Config) *string { return &c.Auth.JWTSecret })},
+	{"auth.tokenTTL", "TOKEN_TTL", "token-ttl", "access token lifetime, e.g. 24h", setDuration(func(c *Config) *Duration { return &c.Auth.TokenTTL })},
+	{"pagination.defaultPageSize", "DEFAULT_PAGE_SIZE", "default-page-size", "page size when none is requested", setInt(func(c *Config) *int { return &c.Pagination.DefaultPageSize })},
+	{"pagination.maxPageSize", "MAX_PAGE_SIZE", "max-page-size", "largest page size a client may request", setInt(func(c *Config) *int { return &c.Pagination.MaxPageSize })},
+	{"log.level", "LOG_LEVEL", "log-level", "minimum log level: debug, info, warn or error", setString(func(c *Config) *string { return &c.Log.Level })},
+	{"log.format", "LOG_FORMAT", "log-format", "log output format: json or text", setString(func(c *Config) *string { return &c.Log.Format })},
+	{"log.slowQuery", "LOG_SLOW_QUERY", "log-slow-query", "queries slower than this are logged as warnings", setDuration(func(c *Config) *Duration { return &c.Log.SlowQuery })},
+	{"tracing.exporter", "TRACING_EXPORTER", "tracing-exporter", "where traces are sent: none, otlp or stdout", setString(func(c *Config) *string { return &c.Tracing.Exporter })},
+	{"tracing.endpoint", "TRACING_ENDPOINT", "tracing-endpoint", "host:port of the OTLP/HTTP collector", setString(func(c *Config) *string { return &c.Tracing.Endpoint })},
+	{"tracing.insecure", "TRACING_INSECURE", "tracing-insecure", "send traces to the collector over plain HTTP", setBool(func(c *Config) *bool { return &c.Tracing.Insecure })},
+	{"tracing.sampleRatio", "TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "fraction of new traces that are sampled, 0 to 1", setFloat(func(c *Config) *float64 { return &c.Tracing.SampleRatio })},
+	{"tracing.serviceName", "TRACING_SERVICE_NAME", "tracing-service-name", "service name reported in the traces", setString(func(c *Config) *string { return &c.Tracing.ServiceName })},
+	{"cache.driver", "CACHE_DRIVER", "cache-driver", "where reference data is cached: memory, redis or none", setString(func(c *Config) *string { return &c.Cache.Driver })},
+	{"cache.ttl", "CACHE_TTL", "cache-ttl", "how long cached reference data is kept", setDuration(func(c *Config) *Duration { return &c.Cache.TTL })},
+	{"cache.size", "CACHE_SIZE", "cache-size", "entries kept by the memory cache", setInt(func(c *Config) *int { return &c.Cache.Size })},
+	{"cache.redis.addr", "REDIS_ADDR", "redis-addr", "host:port of the Redis server of the redis cache", setString(func(c *Config) *string { return &c.Cache.Redis.Addr })},
+	{"cache.redis.password", "REDIS_PASSWORD", "redis-password", "Redis password", setString(func(c *Config) *string { return &c.Cache.Redis.Password })},
+	{"cache.redis.db", "REDIS_DB", "redis-db", "Redis database number", setInt(func(c *Config) *int { return &c.Cache.Redis.DB })},
+	{"cache.redis.poolSize", "REDIS_POOL_SIZE", "redis-pool-size", "idle Redis connections kept", setInt(func(c *Config) *int { return &c.Cache.Redis.PoolSize })},
+	{"cache.redis.timeout", "REDIS_TIMEOUT", "redis-timeout", "deadline of a Redis command", setDuration(func(c *Config) *Duration { return &c.Cache.Redis.Timeout })},
+	{"order.taxRate", "ORDER_TAX_RATE", "order-tax-rate", "VAT charged on orders, 0 to 1, e.g. 0.11", setFloat(func(c *Config) *float64 { return &c.Order.TaxRate })},
+	{"autoMigrate", "AUTO_MIGRATE", "auto-migrate", "apply pending migrations on startup", setBool(func(c *Config) *bool { return &c.AutoMigrate })},
+}
+
+// Load builds the configuration from args, usually os.Args[1:], and returns
+// the arguments left after the flags, i.e. the subcommand and its arguments.
+func Load(args []string) (*Config, []string, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("load .env: %v", err)
+	}
+
+	fset := flag.NewFlagSet("venue-api", flag.ContinueOnError)
+	configFile := fset.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file (env CONFIG_FILE)")
+	flagValues := map[string]*string{}
+	for _, f := range fields {
+		flagValues[f.flag] = fset.String(f.flag, "", fmt.Sprintf("%s (env %s)", f.usage, f.env))
+	}
+	if err := fset.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	errs := []string{}
+	for _, f := range fields {
+		v, ok, err := lookupEnv(f.env)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := f.set(cfg, v); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: invalid value from %s: %v", f.name, f.env, err))
+		}
+	}
+	setFlags := map[string]bool{}
+	fset.Visit(func(fl *flag.Flag) { setFlags[fl.Name] = true })
+	for _, f := range fields {
+		if !setFlags[f.flag] {
+			continue
+		}
+		if err := f.set(cfg, *flagValues[f.flag]); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: invalid value from -%s: %v", f.name, f.flag, err))
+		}
+	}
+
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid configuration:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return cfg, fset.Args(), nil
+}
+
+func (c *Config) validate() []string {
+	errs := []string{}
+	required := func(name, env, v string) {
+		if strings.TrimSpace(v) == "" {
+			errs = append(errs, fmt.Sprintf("%s is required, set %s or %s_FILE", name, env, env))
+		}
+	}
+	port := func(name string, v int) {
+		if v < 1 || v > 65535 {
+			errs = append(errs, fmt.Sprintf("%s must be between 1 and 65535, got %d", name, v))
+		}
+	}
+	positive := func(name string, v Duration) {
+		if v <= 0 {
+			errs = append(errs, fmt.Sprintf("%s must be positive", name))
+		}
+	}
+
+	port("http.port", c.HTTP.Port)
+	port("http.metricsPort", c.HTTP.MetricsPort)
+	if c.HTTP.MetricsPort == c.HTTP.Port {
+		errs = append(errs, "http.metricsPort must differ from http.port")
+	}
+	required("mysql.host", "MYSQL_HOST", c.MySQL.Host)
+	port("mysql.port", c.MySQL.Port)
+	required("mysql.user", "MYSQL_USER", c.MySQL.User)
+	required("mysql.database", "MYSQL_DATABASE", c.MySQL.Database)
+	required("auth.jwtSecret", "JWT_SECRET", c.Auth.JWTSecret)
+	if c.Auth.JWTSecret != "" && len(c.Auth.JWTSecret) < minJWTSecretLength {
+		errs = append(errs, fmt.Sprintf("auth.jwtSecret must be at least %d characters", minJWTSecretLength))
+	}
+	positive("http.readTimeout", c.HTTP.ReadTimeout)
+	positive("http.readHeaderTimeout", c.HTTP.ReadHeaderTimeout)
+	positive("http.writeTimeout", c.HTTP.WriteTimeout)
+	positive("http.idleTimeout", c.HTTP.IdleTimeout)
+	positive("http.requestTimeout", c.HTTP.RequestTimeout)
+	positive("http.exportTimeout", c.HTTP.ExportTimeout)
+	positive("http.shutdownTimeout", c.HTTP.ShutdownTimeout)
+	if c.HTTP.ShutdownDelay < 0 {
+		errs = append(errs, "http.shutdownDelay must not be negative")
+	}
+	if c.HTTP.RequestTimeout > c.HTTP.WriteTimeout || c.HTTP.ExportTimeout > c.HTTP.WriteTimeout {
+		errs = append(errs, "http.requestTimeout and http.exportTimeout must not exceed http.writeTimeout")
+	}
+	if c.MySQL.MaxOpenConns < 0 || c.MySQL.MaxIdleConns < 0 {
+		errs = append(errs, "mysql.maxOpenConns and mysql.maxIdleConns must not be negative")
+	}
+	if c.MySQL.MaxOpenConns > 0 && c.MySQL.MaxIdleConns > c.MySQL.MaxOpenConns {
+		errs = append(errs, "mysql.maxIdleConns must not exceed mysql.maxOpenConns")
+	}
+	positive("mysql.connectTimeout", c.MySQL.ConnectTimeout)
+	positive("auth.tokenTTL", c.Auth.TokenTTL)
+	if c.Pagination.DefaultPageSize < 1 {
+		errs = append(errs, "pagination.defaultPageSize must be positive")
+	}
+	if c.Pagination.MaxPageSize < c.Pagination.DefaultPageSize {
+		errs = append(errs, "pagination.maxPageSize must not be lower than pagination.defaultPageSize")
+	}
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Sprintf("log.level must be debug, info, warn or error, got %q", c.Log.Level))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, fmt.Sprintf("log.format must be json or text, got %q", c.Log.Format))
+	}
+	positive("log.slowQuery", c.Log.SlowQuery)
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if strings.TrimSpace(c.Tracing.Endpoint) == "" {
+			errs = append(errs, "tracing.endpoint is required with the otlp exporter")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("tracing.exporter must be none, otlp or stdout, got %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, "tracing.sampleRatio must be between 0 and 1")
+	}
+	switch c.Cache.Driver {
+	case "none":
+	case "memory":
+		if c.Cache.Size < 1 {
+			errs = append(errs, "cache.size must be positive")
+		}
+	case "redis":
+		if strings.TrimSpace(c.Cache.Redis.Addr) == "" {
+			errs = append(errs, "cache.redis.addr is required with the redis driver")
+		}
+		if c.Cache.Redis.PoolSize < 1 {
+			errs = append(errs, "cache.redis.poolSize must be positive")
+		}
+		positive("cache.redis.timeout", c.Cache.Redis.Timeout)
+	default:
+		errs = append(errs, fmt.Sprintf("cache.driver must be memory, redis or none, got %q", c.Cache.Driver))
+	}
+	if c.Cache.Driver != "none" {
+		positive("cache.ttl", c.Cache.TTL)
+	}
+	if c.Order.TaxRate < 0 || c.Order.TaxRate > 1 {
+		errs = append(errs, "order.taxRate must be between 0 and 1")
+	}
+	return errs
+}
+
+func loadFile(cfg *Config, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %v", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(content, cfg)
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(content)).DisallowUnknownFields().Decode(cfg)
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// lookupEnv reads name from the environment, or from the file named by
+// name_FILE when that is set instead.
+func lookupEnv(name string) (string, bool, error) {
+	if v, ok := os.LookupEnv(name); ok {
+		return v, true, nil
+	}
+	path, ok := os.LookupEnv(name + "_FILE")
+	if !ok {
+		return "", false, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("read %s_FILE: %v", name, err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), true, nil
+}
+
+func setString(field func(c *Config) *string) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		*field(c) = v
+		return nil
+	}
+}
+
+func setInt(field func(c *Config) *int) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func setBool(field func(c *Config) *bool) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+func setFloat(field func(c *Config) *float64) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return err
+		}
+		*field(c) = f
+		return nil
+	}
+}
+
+func setDuration(field func(c *Config) *Duration) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		return field(c).UnmarshalText([]byte(strings.TrimSpace(v)))
+	}
+}
+
+func splitList(v string) []string {
+	out := []string{}
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
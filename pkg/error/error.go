@@ -11,12 +11,62 @@ var (
 	ErrGeneralDB         = errors.New("DB error")
 	ErrInternal          = errors.New("internal server error")
 	ErrUnauthorized      = errors.New("err unathorized")
+	ErrForbidden         = errors.New("forbidden")
+	ErrConflict          = errors.New("conflict")
+	ErrTooManyRequests   = errors.New("too many requests")
+	ErrUnprocessable     = errors.New("unprocessable")
 )
 
+// Codes of the error kinds, sent to clients when no more specific code is
+// set. Codes are part of the API contract and must never change.
+const (
+	CodeBadRequest      = "BAD_REQUEST"
+	CodeNotFound        = "NOT_FOUND"
+	CodeInternal        = "INTERNAL_ERROR"
+	CodeUnauthorized    = "UNAUTHORIZED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeConflict        = "CONFLICT"
+	CodeTooManyRequests = "TOO_MANY_REQUESTS"
+	CodeUnprocessable   = "UNPROCESSABLE"
+	CodeTimeout         = "TIMEOUT"
+)
+
+var codeByType = []struct {
+	typeErr error
+	code    string
+}{
+	{ErrGeneralBadRequest, CodeBadRequest},
+	{ErrGeneralNotFound, CodeNotFound},
+	{ErrUnauthorized, CodeUnauthorized},
+	{ErrForbidden, CodeForbidden},
+	{ErrConflict, CodeConflict},
+	{ErrTooManyRequests, CodeTooManyRequests},
+	{ErrUnprocessable, CodeUnprocessable},
+}
+
 type InternalError struct {
+	// UserErrMsg is a literal message, when empty the message is rendered
+	// from the catalog entry of MsgKey, or of Code, in the client's locale.
 	UserErrMsg  string
 	OriginalErr error
 	TypeErr     error
+	// Code identifies the error for clients, see GetCode.
+	Code string
+	// MsgKey selects a variant of the message of Code.
+	MsgKey string
+	// Args are the arguments of the catalog message.
+	Args []any
+	// Details lists the offending fields of the request, if any.
+	Details []Detail
+}
+
+// Detail is a problem with one field of the request. When Message is empty
+// it is rendered from the field message of Code with Args.
+type Detail struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Args    []any  `json:"-"`
 }
 
 func New(typeErr error, originalErr error, userErrMsg ...string) error {
@@ -31,28 +81,128 @@ func New(typeErr error, originalErr error, userErrMsg ...string) error {
 	}
 }
 
+// NewWithCode returns an error with a stable code clients can rely on, its
+// message is the catalog entry of the code rendered with args.
+func NewWithCode(typeErr error, code string, originalErr error, args ...any) error {
+	return &InternalError{
+		OriginalErr: originalErr,
+		TypeErr:     typeErr,
+		Code:        code,
+		Args:        args,
+	}
+}
+
+// NewWithKey is NewWithCode with the message of the catalog entry key, for
+// codes having several messages.
+func NewWithKey(typeErr error, code, key string, originalErr error, args ...any) error {
+	err := NewWithCode(typeErr, code, originalErr, args...).(*InternalError)
+	err.MsgKey = key
+	return err
+}
+
+// WithDetails attaches field details to err when it is an InternalError.
+func WithDetails(err error, details ...Detail) error {
+	var intErr *InternalError
+	if errors.As(err, &intErr) {
+		intErr.Details = append(intErr.Details, details...)
+	}
+	return err
+}
+
 func (d *InternalError) Error() string {
-	if d.UserErrMsg == "" {
-		return "unexpected error"
+	if d.UserErrMsg != "" {
+		return d.UserErrMsg
 	}
-	return d.UserErrMsg
+	if key, _ := d.message(); key != "" {
+		return key
+	}
+	return "unexpected error"
+}
+
+func (d *InternalError) message() (string, []any) {
+	if d.MsgKey != "" {
+		return d.MsgKey, d.Args
+	}
+	return d.Code, d.Args
+}
+
+// Unwrap exposes the original error to errors.Is and errors.As.
+func (d *InternalError) Unwrap() error {
+	return d.OriginalErr
+}
+
+// Is makes errors.Is(err, ErrGeneralNotFound) match an InternalError of that
+// type, wherever it sits in the chain.
+func (d *InternalError) Is(target error) bool {
+	return d.TypeErr != nil && d.TypeErr == target
 }
 
 func toInternalErr(err error) *InternalError {
 	var intErr *InternalError
-	e, ok := err.(*InternalError)
-	if ok {
-		intErr = e
-	} else {
-		intErr = &InternalError{
-			UserErrMsg:  "internal service error",
-			OriginalErr: fmt.Errorf("unexpected err: %v", err),
-			TypeErr:     ErrInternal,
+	if errors.As(err, &intErr) {
+		return intErr
+	}
+	// A bare or wrapped kind, e.g. ErrGeneralBadRequest, keeps its type.
+	for _, c := range codeByType {
+		if errors.Is(err, c.typeErr) {
+			return &InternalError{
+				OriginalErr: err,
+				TypeErr:     c.typeErr,
+			}
 		}
 	}
-	return intErr
+	return &InternalError{
+		OriginalErr: fmt.Errorf("unexpected err: %v", err),
+		TypeErr:     ErrInternal,
+	}
 }
 
 func GetTypeErr(err error) error {
 	return toInternalErr(err).TypeErr
 }
+
+// IsClientErr reports whether err is caused by the request, as opposed to a
+// failure of the server or of the database.
+func IsClientErr(err error) bool {
+	typeErr := GetTypeErr(err)
+	return typeErr != ErrInternal && typeErr != ErrGeneralDB
+}
+
+// GetCode returns the code of err, the one of its type when none is set.
+func GetCode(err error) string {
+	intErr := toInternalErr(err)
+	if intErr.Code != "" {
+		return intErr.Code
+	}
+	for _, c := range codeByType {
+		if intErr.TypeErr == c.typeErr {
+			return c.code
+		}
+	}
+	return CodeInternal
+}
+
+// GetUserErrMsg returns the literal message of err, if any.
+func GetUserErrMsg(err error) string {
+	return toInternalErr(err).UserErrMsg
+}
+
+// GetMessage returns the catalog key of the message of err and its
+// arguments. The key is empty when err has no specific code.
+func GetMessage(err error) (string, []any) {
+	return toInternalErr(err).message()
+}
+
+// GetDetails returns the field details of err, if any.
+func GetDetails(err error) []Detail {
+	return toInternalErr(err).Details
+}
+
+// GetOriginalErr returns the underlying cause of err, err itself when it is
+// not an InternalError.
+func GetOriginalErr(err error) error {
+	if intErr, ok := err.(*InternalError); ok && intErr.OriginalErr != nil {
+		return intErr.OriginalErr
+	}
+	return err
+}
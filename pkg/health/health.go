@@ -0,0 +1,91 @@
+// Package health runs the readiness checks of the app's dependencies and
+// tracks whether the app is draining before shutdown.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports an error when the dependency it covers is not usable.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a check. Only its name and status are served, the
+// latency and error are for the logs.
+type Result struct {
+	Name    string        `json:"name"`
+	Status  string        `json:"status"`
+	Latency time.Duration `json:"-"`
+	Error   string        `json:"-"`
+}
+
+type check struct {
+	name string
+	fn   Check
+}
+
+type Checker struct {
+	checks   []check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// New returns a checker giving each check at most timeout to answer.
+func New(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a check, it must be called before serving.
+func (h *Checker) Add(name string, fn Check) {
+	h.checks = append(h.checks, check{name: name, fn: fn})
+}
+
+// Drain makes the app report itself as not ready from now on, so load
+// balancers stop sending traffic before the server shuts down.
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *Checker) Draining() bool {
+	return h.draining.Load()
+}
+
+// Ready runs all the checks concurrently and reports whether every one of
+// them passed and the app is not draining.
+func (h *Checker) Ready(ctx context.Context) (bool, []*Result) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	results := make([]*Result, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			start := time.Now()
+			res := &Result{Name: c.name, Status: StatusUp}
+			if err := c.fn(ctx); err != nil {
+				res.Status = StatusDown
+				res.Error = err.Error()
+			}
+			res.Latency = time.Since(start)
+			results[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	ready := !h.Draining()
+	for _, res := range results {
+		if res.Status != StatusUp {
+			ready = false
+		}
+	}
+	return ready, results
+}
@@ -0,0 +1,442 @@
+package i18n
+
+import (
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+)
+
+// catalog holds every user-facing message, keyed by error code or by one of
+// the variants declared in core/entity, then by locale. Messages are fmt
+// formats, the validation ones get the field name and the rule parameter as
+// %[1]s and %[2]s.
+var catalog = map[string]map[string]string{
+	// Kinds of errors, used when no more specific code is set.
+	errutil.CodeBadRequest: {
+		Indonesian: "permintaan tidak valid",
+		English:    "invalid request",
+	},
+	errutil.CodeNotFound: {
+		Indonesian: "data tidak ditemukan",
+		English:    "not found",
+	},
+	errutil.CodeInternal: {
+		Indonesian: "terjadi kesalahan pada server",
+		English:    "something went wrong on our side",
+	},
+	errutil.CodeUnauthorized: {
+		Indonesian: "anda tidak diizinkan mengakses aplikasi ini",
+		English:    "you are not allowed to access this application",
+	},
+	errutil.CodeForbidden: {
+		Indonesian: "anda tidak memiliki akses ke fitur ini",
+		English:    "you don't have access to this feature",
+	},
+	errutil.CodeConflict: {
+		Indonesian: "data bertentangan dengan data yang sudah ada",
+		English:    "the request conflicts with existing data",
+	},
+	errutil.CodeTooManyRequests: {
+		Indonesian: "terlalu banyak permintaan, silakan coba lagi nanti",
+		English:    "too many requests, please try again later",
+	},
+	errutil.CodeUnprocessable: {
+		Indonesian: "permintaan tidak dapat diproses",
+		English:    "the request can't be processed",
+	},
+	errutil.CodeTimeout: {
+		Indonesian: "permintaan melebihi batas waktu, silakan coba lagi",
+		English:    "the request took too long, please try again",
+	},
+
+	// Authentication.
+	entity.ErrCodeInvalidCredentials: {
+		Indonesian: "Username atau password salah",
+		English:    "Wrong username or password",
+	},
+	entity.ErrCodeInvalidToken: {
+		Indonesian: "anda tidak diizinkan mengakses aplikasi ini",
+		English:    "you are not allowed to access this application",
+	},
+	entity.ErrCodeRoleNotAllowed: {
+		Indonesian: "anda tidak memiliki akses ke fitur ini",
+		English:    "you don't have access to this feature",
+	},
+	entity.ErrCodeEmailTaken: {
+		Indonesian: "Email sudah terdaftar di sistem",
+		English:    "The email is already registered",
+	},
+	FieldKey(entity.ErrCodeEmailTaken): {
+		Indonesian: "email sudah terdaftar",
+		English:    "the email is already registered",
+	},
+
+	// Lookups.
+	entity.ErrCodeVenueNotFound: {
+		Indonesian: "venue tidak ditemukan",
+		English:    "venue not found",
+	},
+	entity.ErrCodeUserNotFound: {
+		Indonesian: "pengguna tidak ditemukan",
+		English:    "user not found",
+	},
+	entity.ErrCodeCategoryNotFound: {
+		Indonesian: "category tidak ditemukan",
+		English:    "category not found",
+	},
+	entity.ErrCodePackageNotFound: {
+		Indonesian: "package tidak ditemukan",
+		English:    "package not found",
+	},
+	entity.MsgOrderPackageNotFound: {
+		Indonesian: "Tidak dapat membuat order untuk tanggal %s dikarenakan package id %d tidak ditemukan",
+		English:    "Can't order for %s because package %d doesn't exist",
+	},
+	entity.ErrCodeOrderNotFound: {
+		Indonesian: "order tidak ditemukan",
+		English:    "order not found",
+	},
+	entity.ErrCodeCalendarNotFound: {
+		Indonesian: "kalender tidak ditemukan",
+		English:    "calendar not found",
+	},
+	entity.ErrCodeReportNotFound: {
+		Indonesian: "laporan tidak ditemukan",
+		English:    "report not found",
+	},
+
+	// Orders.
+	entity.ErrCodeDateInPast: {
+		Indonesian: "Tidak dapat membuat order untuk tanggal %s dikarenakan tanggal sudah lewat",
+		English:    "Can't order for %s because the date has passed",
+	},
+	FieldKey(entity.ErrCodeDateInPast): {
+		Indonesian: "tanggal sudah lewat",
+		English:    "the date has passed",
+	},
+	entity.ErrCodeDateUnavailable: {
+		Indonesian: "Tidak dapat membuat order untuk tanggal %s dikarenakan tempat sudah di reservasi",
+		English:    "Can't order for %s because the venue is already booked",
+	},
+	FieldKey(entity.ErrCodeDateUnavailable): {
+		Indonesian: "tanggal sudah di reservasi",
+		English:    "the date is already booked",
+	},
+	entity.ErrCodeAddonUnavailable: {
+		Indonesian: "add-on id %d tidak tersedia untuk package ini",
+		English:    "add-on %d isn't available for this package",
+	},
+	entity.MsgAddonQuantityBelowMin: {
+		Indonesian: "jumlah minimal %s adalah %d %s",
+		English:    "the minimum quantity of %s is %d %s",
+	},
+	entity.MsgAddonQuantityAboveMax: {
+		Indonesian: "jumlah maksimal %s adalah %d %s",
+		English:    "the maximum quantity of %s is %d %s",
+	},
+
+	// Promos.
+	entity.ErrCodePromoNotFound: {
+		Indonesian: "kode promo tidak ditemukan",
+		English:    "promo code not found",
+	},
+	entity.ErrCodePromoCodeTaken: {
+		Indonesian: "kode promo sudah digunakan",
+		English:    "the promo code is already used",
+	},
+	entity.ErrCodePromoInactive: {
+		Indonesian: "kode promo sedang tidak berlaku",
+		English:    "the promo code isn't active",
+	},
+	entity.ErrCodePromoNotApplicable: {
+		Indonesian: "kode promo tidak berlaku",
+		English:    "the promo code doesn't apply",
+	},
+	entity.MsgPromoNotForPackage: {
+		Indonesian: "kode promo tidak berlaku untuk package ini",
+		English:    "the promo code doesn't apply to this package",
+	},
+	entity.MsgPromoNotForVenue: {
+		Indonesian: "kode promo tidak berlaku untuk venue ini",
+		English:    "the promo code doesn't apply to this venue",
+	},
+	entity.MsgPromoNotForCity: {
+		Indonesian: "kode promo tidak berlaku untuk kota ini",
+		English:    "the promo code doesn't apply to this city",
+	},
+	entity.ErrCodePromoMinSpend: {
+		Indonesian: "minimal transaksi untuk kode promo ini adalah %.0f",
+		English:    "the minimum spend for this promo code is %.0f",
+	},
+	entity.ErrCodePromoQuotaExhausted: {
+		Indonesian: "kuota kode promo sudah habis",
+		English:    "the promo code has run out",
+	},
+	entity.ErrCodePromoUserLimit: {
+		Indonesian: "anda sudah mencapai batas penggunaan kode promo ini",
+		English:    "you have reached the usage limit of this promo code",
+	},
+	entity.ErrCodePromoInvalid: {
+		Indonesian: "data promo tidak valid",
+		English:    "invalid promo",
+	},
+	entity.MsgPromoCodeRequired: {
+		Indonesian: "kode promo tidak boleh kosong",
+		English:    "the promo code can't be empty",
+	},
+	entity.MsgPromoDiscountType: {
+		Indonesian: "tipe diskon harus percentage atau fixed",
+		English:    "the discount type must be percentage or fixed",
+	},
+	entity.MsgPromoPercentageTooHigh: {
+		Indonesian: "diskon persentase maksimal 100",
+		English:    "a percentage discount can't exceed 100",
+	},
+	entity.MsgPromoDiscountNotPositive: {
+		Indonesian: "nilai diskon harus lebih dari 0",
+		English:    "the discount value must be greater than 0",
+	},
+	entity.MsgPromoNegativeLimit: {
+		Indonesian: "batasan promo tidak boleh negatif",
+		English:    "promo limits can't be negative",
+	},
+	entity.MsgPromoPeriod: {
+		Indonesian: "waktu berakhir promo harus setelah waktu mulai",
+		English:    "the promo must end after it starts",
+	},
+
+	// Price rules.
+	entity.ErrCodePriceRuleNotFound: {
+		Indonesian: "aturan harga tidak ditemukan",
+		English:    "price rule not found",
+	},
+	entity.ErrCodePriceRuleInvalid: {
+		Indonesian: "data aturan harga tidak valid",
+		English:    "invalid price rule",
+	},
+	entity.MsgPriceRuleDaysRequired: {
+		Indonesian: "aturan day_of_week wajib memiliki daysOfWeek",
+		English:    "a day_of_week rule needs daysOfWeek",
+	},
+	entity.MsgPriceRuleDateRequired: {
+		Indonesian: "aturan date_range wajib memiliki startDate dan endDate, aturan specific_date wajib memiliki startDate",
+		English:    "a date_range rule needs startDate and endDate, a specific_date rule needs startDate",
+	},
+	entity.MsgPriceRulePeriod: {
+		Indonesian: "endDate tidak boleh sebelum startDate",
+		English:    "endDate can't be before startDate",
+	},
+	entity.MsgPriceRuleLeadDays: {
+		Indonesian: "maxLeadDays harus 0 atau tidak kurang dari minLeadDays",
+		English:    "maxLeadDays must be 0 or at least minLeadDays",
+	},
+	entity.MsgPriceRuleAdjustment: {
+		Indonesian: "penyesuaian persentase tidak boleh di bawah -100 dan harga override tidak boleh negatif",
+		English:    "a percentage adjustment can't be below -100 and an override price can't be negative",
+	},
+
+	// Imports.
+	entity.ErrCodeImportInvalid: {
+		Indonesian: "terdapat %d kesalahan pada data import",
+		English:    "the import data has %d errors",
+	},
+	entity.MsgImportFormat: {
+		Indonesian: "format import harus csv atau json",
+		English:    "the import format must be csv or json",
+	},
+	entity.MsgImportFileRequired: {
+		Indonesian: "file import wajib diunggah",
+		English:    "the import file is required",
+	},
+	entity.MsgImportFileUnreadable: {
+		Indonesian: "file import tidak dapat dibaca",
+		English:    "the import file can't be read",
+	},
+	entity.MsgImportCSVHeader: {
+		Indonesian: "header CSV tidak dapat dibaca",
+		English:    "the CSV header can't be read",
+	},
+	entity.MsgImportCSV: {
+		Indonesian: "file CSV tidak valid: %s",
+		English:    "invalid CSV file: %s",
+	},
+	entity.MsgImportJSON: {
+		Indonesian: "file JSON tidak valid: %s",
+		English:    "invalid JSON file: %s",
+	},
+	entity.MsgImportMissingColumn: {
+		Indonesian: "kolom %s wajib ada",
+		English:    "the %s column is required",
+	},
+	entity.MsgImportRowRequired: {
+		Indonesian: "wajib diisi",
+		English:    "is required",
+	},
+	entity.MsgImportRowInteger: {
+		Indonesian: "harus berupa bilangan bulat",
+		English:    "must be an integer",
+	},
+	entity.MsgImportRowNumber: {
+		Indonesian: "harus berupa angka",
+		English:    "must be a number",
+	},
+	entity.MsgImportRowNegative: {
+		Indonesian: "tidak boleh negatif",
+		English:    "can't be negative",
+	},
+	entity.MsgImportRowTooLong: {
+		Indonesian: "maksimal %d karakter",
+		English:    "must have at most %d characters",
+	},
+	entity.MsgImportRowType: {
+		Indonesian: "type harus venue, gallery, category atau package",
+		English:    "type must be venue, gallery, category or package",
+	},
+	entity.MsgImportRowParent: {
+		Indonesian: "parent %q tidak ditemukan di file",
+		English:    "parent %q isn't in the file",
+	},
+	entity.MsgImportRowDuplicate: {
+		Indonesian: "duplikat %s di dalam file",
+		English:    "duplicate %s in the file",
+	},
+	entity.MsgImportRowCity: {
+		Indonesian: "kota %d tidak ditemukan",
+		English:    "city %d not found",
+	},
+	entity.MsgImportRowEmail: {
+		Indonesian: "format email tidak valid",
+		English:    "invalid email format",
+	},
+	entity.MsgImportRowTimezone: {
+		Indonesian: "timezone tidak valid",
+		English:    "invalid timezone",
+	},
+
+	// Requests.
+	entity.ErrCodeInvalidID: {
+		Indonesian: "format id tidak valid",
+		English:    "invalid id format",
+	},
+	entity.ErrCodeInvalidDateRange: {
+		Indonesian: "tanggal akhir harus setelah tanggal awal",
+		English:    "the end date must be after the start date",
+	},
+	entity.ErrCodeInvalidGroupBy: {
+		Indonesian: "groupBy tidak valid",
+		English:    "invalid groupBy",
+	},
+	entity.ErrCodeNotReady: {
+		Indonesian: "layanan belum siap menerima permintaan",
+		English:    "the service isn't ready to take requests",
+	},
+	entity.ErrCodeLocaleNotSupported: {
+		Indonesian: "terjemahan hanya dapat ditulis dalam bahasa: %s",
+		English:    "translations can only be written in: %s",
+	},
+	entity.ErrCodeFieldNotTranslatable: {
+		Indonesian: "terdapat field yang tidak dapat diterjemahkan",
+		English:    "some fields can't be translated",
+	},
+	FieldKey(entity.ErrCodeFieldNotTranslatable): {
+		Indonesian: "field tidak dapat diterjemahkan, pilihan: %s",
+		English:    "the field can't be translated, options: %s",
+	},
+	entity.ErrCodeInvalidCursor: {
+		Indonesian: "cursor tidak valid, mulai lagi dari halaman pertama",
+		English:    "invalid cursor, start again from the first page",
+	},
+	FieldKey(entity.ErrCodeInvalidCursor): {
+		Indonesian: "cursor tidak valid atau tidak sesuai dengan sort",
+		English:    "the cursor is invalid or doesn't match the sort",
+	},
+	entity.ErrCodeSortNotSupported: {
+		Indonesian: "urutan tidak didukung untuk pencarian ini",
+		English:    "this sort isn't supported by the search",
+	},
+	FieldKey(entity.ErrCodeSortNotSupported): {
+		Indonesian: "urutan berdasarkan harga tidak dapat digabung dengan tanggal",
+		English:    "sorting by price can't be combined with a date",
+	},
+	entity.ErrCodeValidationFailed: {
+		Indonesian: "data yang dikirim tidak valid",
+		English:    "the submitted data is invalid",
+	},
+
+	// Validation rules, see pkg/validation.
+	ValidationKey("required"): {
+		Indonesian: "%[1]s wajib diisi",
+		English:    "%[1]s is required",
+	},
+	ValidationKey("email"): {
+		Indonesian: "%[1]s harus berupa alamat email yang valid",
+		English:    "%[1]s must be a valid email address",
+	},
+	ValidationKey("date"): {
+		Indonesian: "%[1]s harus berformat YYYY-MM-DD",
+		English:    "%[1]s must be formatted as YYYY-MM-DD",
+	},
+	ValidationKey("month"): {
+		Indonesian: "%[1]s harus berformat YYYY-MM",
+		English:    "%[1]s must be formatted as YYYY-MM",
+	},
+	ValidationKey("password"): {
+		Indonesian: "%[1]s minimal 8 karakter dan harus mengandung huruf serta angka",
+		English:    "%[1]s must have at least 8 characters with letters and digits",
+	},
+	ValidationKey("oneof"): {
+		Indonesian: "%[1]s harus salah satu dari %[2]s",
+		English:    "%[1]s must be one of %[2]s",
+	},
+	ValidationKey("each_oneof"): {
+		Indonesian: "setiap nilai %[1]s harus salah satu dari %[2]s",
+		English:    "each value of %[1]s must be one of %[2]s",
+	},
+	ValidationKey("min"): {
+		Indonesian: "%[1]s minimal %[2]s",
+		English:    "%[1]s must be at least %[2]s",
+	},
+	ValidationKey("gte"): {
+		Indonesian: "%[1]s minimal %[2]s",
+		English:    "%[1]s must be at least %[2]s",
+	},
+	ValidationKey("gt"): {
+		Indonesian: "%[1]s harus lebih dari %[2]s",
+		English:    "%[1]s must be greater than %[2]s",
+	},
+	ValidationKey("max"): {
+		Indonesian: "%[1]s maksimal %[2]s",
+		English:    "%[1]s must be at most %[2]s",
+	},
+	ValidationKey("lte"): {
+		Indonesian: "%[1]s maksimal %[2]s",
+		English:    "%[1]s must be at most %[2]s",
+	},
+	ValidationKey("lt"): {
+		Indonesian: "%[1]s harus kurang dari %[2]s",
+		English:    "%[1]s must be less than %[2]s",
+	},
+	ValidationKey("min.string"): {
+		Indonesian: "%[1]s minimal %[2]s karakter",
+		English:    "%[1]s must have at least %[2]s characters",
+	},
+	ValidationKey("max.string"): {
+		Indonesian: "%[1]s maksimal %[2]s karakter",
+		English:    "%[1]s must have at most %[2]s characters",
+	},
+	ValidationKey("number"): {
+		Indonesian: "%[1]s harus berupa angka",
+		English:    "%[1]s must be a number",
+	},
+	ValidationKey("boolean"): {
+		Indonesian: "%[1]s harus bernilai true atau false",
+		English:    "%[1]s must be true or false",
+	},
+	ValidationKey("type"): {
+		Indonesian: "%[1]s memiliki tipe yang tidak valid",
+		English:    "%[1]s has an invalid type",
+	},
+	ValidationKey("invalid"): {
+		Indonesian: "%[1]s tidak valid",
+		English:    "%[1]s is invalid",
+	},
+}
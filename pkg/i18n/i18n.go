@@ -0,0 +1,147 @@
+// Package i18n renders the user-facing messages in the locale negotiated for
+// the request. Messages live in a single catalog keyed by error code.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+)
+
+const (
+	Indonesian = "id"
+	English    = "en"
+	// Default is used when the client accepts none of the supported locales
+	// and for messages missing from the catalog of a locale.
+	Default = Indonesian
+)
+
+// Supported lists the locales having a catalog.
+var Supported = []string{Indonesian, English}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying the locale of the request.
+func NewContext(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, locale)
+}
+
+// FromContext returns the locale carried by ctx, Default when there is none.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(ctxKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return Default
+}
+
+// IsSupported reports whether locale has a catalog.
+func IsSupported(locale string) bool {
+	for _, l := range Supported {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the supported locale the client prefers from an
+// Accept-Language header, e.g. "en-US,en;q=0.9,id;q=0.8". Regions are
+// ignored, en-GB matches en.
+func Negotiate(acceptLanguage string) string {
+	type tag struct {
+		lang string
+		q    float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		lang := strings.ToLower(strings.TrimSpace(fields[0]))
+		if i := strings.IndexAny(lang, "-_"); i >= 0 {
+			lang = lang[:i]
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q > 0 && IsSupported(lang) {
+			tags = append(tags, tag{lang, q})
+		}
+	}
+	if len(tags) == 0 {
+		return Default
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	return tags[0].lang
+}
+
+// T renders the message of key in locale with the given arguments, falling
+// back to Default when the locale lacks it and to the key itself when no
+// catalog has it.
+func T(locale, key string, args ...any) string {
+	msg, ok := catalog[key][locale]
+	if !ok {
+		if msg, ok = catalog[key][Default]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 || !strings.Contains(msg, "%") {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Has reports whether the catalog has a message for key.
+func Has(key string) bool {
+	_, ok := catalog[key]
+	return ok
+}
+
+// Error renders the user message of err in locale. A literal message given
+// when the error was created wins over the catalog.
+func Error(locale string, err error) string {
+	if msg := errutil.GetUserErrMsg(err); msg != "" {
+		return msg
+	}
+	key, args := errutil.GetMessage(err)
+	if !Has(key) {
+		key = errutil.GetCode(err)
+	}
+	return T(locale, key, args...)
+}
+
+// Details renders the field details of err in locale. Details created with a
+// message keep it, the others get the field message of their code.
+func Details(locale string, err error) []errutil.Detail {
+	details := errutil.GetDetails(err)
+	if len(details) == 0 {
+		return nil
+	}
+	out := make([]errutil.Detail, len(details))
+	for i, d := range details {
+		if d.Message == "" {
+			d.Message = T(locale, FieldKey(d.Code), d.Args...)
+		}
+		out[i] = d
+	}
+	return out
+}
+
+// FieldKey is the catalog key of the message of a field detail with the
+// given code.
+func FieldKey(code string) string {
+	return "field." + code
+}
+
+// ValidationKey is the catalog key of the message of a violated validation
+// rule, rendered with the field name and the rule parameter.
+func ValidationKey(rule string) string {
+	return "validation." + rule
+}
@@ -0,0 +1,128 @@
+// Package ical writes RFC 5545 calendars, only the subset needed to publish
+// bookings as events is supported.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxLineOctets = 75
+
+type Calendar struct {
+	ProdID   string
+	Name     string
+	Timezone string
+	Events   []*Event
+}
+
+// Event is an all-day event when AllDay is set, Start and End are then only
+// read for their calendar date and End is exclusive.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Description string
+	Location    string
+	Status      string
+	Created     time.Time
+}
+
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeLine(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", c.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.Timezone != "" {
+		line("X-WR-TIMEZONE", c.Timezone)
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, ev := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", ev.UID)
+		line("DTSTAMP", stamp)
+		if ev.AllDay {
+			writeLine(&buf, "DTSTART;VALUE=DATE:"+ev.Start.Format("20060102"))
+			writeLine(&buf, "DTEND;VALUE=DATE:"+ev.End.Format("20060102"))
+		} else {
+			line("DTSTART", ev.Start.UTC().Format("20060102T150405Z"))
+			line("DTEND", ev.End.UTC().Format("20060102T150405Z"))
+		}
+		if !ev.Created.IsZero() {
+			line("CREATED", ev.Created.UTC().Format("20060102T150405Z"))
+		}
+		line("SUMMARY", escape(ev.Summary))
+		if ev.Description != "" {
+			line("DESCRIPTION", escape(ev.Description))
+		}
+		if ev.Location != "" {
+			line("LOCATION", escape(ev.Location))
+		}
+		if ev.Status != "" {
+			line("STATUS", ev.Status)
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (c *Calendar) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeLine folds the content line at 75 octets without splitting a UTF-8
+// sequence, continuation lines start with a single space.
+func writeLine(buf *bytes.Buffer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		buf.WriteString(s[:cut])
+		buf.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space of the continuation counts toward its length.
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(s)
+	buf.WriteString("\r\n")
+}
+
+func escape(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// UID builds a globally unique identifier for a record of the given kind.
+func UID(kind string, id int, domain string) string {
+	return fmt.Sprintf("%s-%d@%s", kind, id, domain)
+}
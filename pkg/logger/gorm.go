@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Gorm adapts the logger of the request to gorm: failed queries are logged
+// as errors, slow ones as warnings and every query at debug level.
+type Gorm struct {
+	slowQuery time.Duration
+	level     gormlogger.LogLevel
+}
+
+func NewGorm(slowQuery time.Duration) *Gorm {
+	return &Gorm{
+		slowQuery: slowQuery,
+		level:     gormlogger.Warn,
+	}
+}
+
+func (g *Gorm) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *g
+	copied.level = level
+	return &copied
+}
+
+func (g *Gorm) Info(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= gormlogger.Info {
+		FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *Gorm) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= gormlogger.Warn {
+		FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *Gorm) Error(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= gormlogger.Error {
+		FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *Gorm) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.level <= gormlogger.Silent {
+		return
+	}
+	l := FromContext(ctx)
+	elapsed := time.Since(begin)
+	attrs := func() []any {
+		query, rows := fc()
+		return []any{slog.String("sql", query), slog.Int64("rows", rows), slog.Duration("elapsed", elapsed)}
+	}
+	switch {
+	// A missing record is an expected outcome, the caller decides whether
+	// it is an error. A canceled query is reported by the request itself.
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, context.Canceled) && g.level >= gormlogger.Error:
+		l.ErrorContext(ctx, "query failed", append(attrs(), slog.String("error", err.Error()))...)
+	case g.slowQuery > 0 && elapsed > g.slowQuery && g.level >= gormlogger.Warn:
+		l.WarnContext(ctx, "slow query", attrs()...)
+	case l.Enabled(ctx, slog.LevelDebug):
+		l.DebugContext(ctx, "query", attrs()...)
+	}
+}
@@ -0,0 +1,143 @@
+// Package metrics holds the Prometheus collectors of the app and serves them
+// in the exposition format.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "venue_api"
+
+// Reasons an order is rejected, used as the reason label of
+// venue_api_orders_rejected_total.
+const (
+	OrderRejectedPackageNotFound = "package_not_found"
+	OrderRejectedPastDate        = "past_date"
+	OrderRejectedUnavailableDate = "unavailable_date"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests   *prometheus.CounterVec
+	httpDuration   *prometheus.HistogramVec
+	repoDuration   *prometheus.HistogramVec
+	repoErrors     *prometheus.CounterVec
+	cacheLookups   *prometheus.CounterVec
+	ordersCreated  prometheus.Counter
+	ordersRejected *prometheus.CounterVec
+	loginFailures  prometheus.Counter
+}
+
+// New registers the collectors on a registry of its own, together with the
+// Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time spent serving HTTP requests, by route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		repoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_duration_seconds",
+			Help:      "Time spent in repository methods.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"method"}),
+		repoErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "repository_errors_total",
+			Help:      "Repository calls that failed on the server side, a record not found or a conflict is not counted.",
+		}, []string{"method"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Repository reads looked up in the cache, by method and result (hit or miss).",
+		}, []string{"method", "result"}),
+		ordersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_created_total",
+			Help:      "Orders successfully created.",
+		}),
+		ordersRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_rejected_total",
+			Help:      "Orders refused before being created, by reason.",
+		}, []string{"reason"}),
+		loginFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_failures_total",
+			Help:      "Logins refused because of a wrong email or password.",
+		}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.repoDuration,
+		m.repoErrors,
+		m.cacheLookups,
+		m.ordersCreated,
+		m.ordersRejected,
+		m.loginFailures,
+	)
+	return m
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the collected metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+func (m *Metrics) ObserveRepository(method string, elapsed time.Duration, failed bool) {
+	m.repoDuration.WithLabelValues(method).Observe(elapsed.Seconds())
+	if failed {
+		m.repoErrors.WithLabelValues(method).Inc()
+	}
+}
+
+func (m *Metrics) ObserveCache(method string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(method, result).Inc()
+}
+
+func (m *Metrics) OrderCreated() {
+	m.ordersCreated.Inc()
+}
+
+func (m *Metrics) OrderRejected(reason string) {
+	m.ordersRejected.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) LoginFailed() {
+	m.loginFailures.Inc()
+}
@@ -0,0 +1,291 @@
+// Package migrate applies versioned SQL files to a MySQL database. Files are
+// named <version>_<name>.up.sql and optionally <version>_<name>.down.sql, the
+// applied versions are recorded with the checksum of their up file so edits
+// to an already applied migration are detected.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const lockTimeoutSeconds = 60
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type Status struct {
+	Migration *Migration
+	AppliedAt *time.Time
+}
+
+type applied struct {
+	Version   int64
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Load reads the migrations in dir of fsys ordered by version.
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("version %d is used by both %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(content)
+			sum := sha256.Sum256(content)
+			mig.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			mig.Down = string(content)
+		}
+	}
+
+	out := []*Migration{}
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", mig.Version, mig.Name)
+		}
+		out = append(out, mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+type Migrator struct {
+	db         *sql.DB
+	table      string
+	migrations []*Migration
+}
+
+// New returns a migrator recording its versions in table, which is created
+// when missing.
+func New(db *sql.DB, table string, migrations []*Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		table:      table,
+		migrations: migrations,
+	}
+}
+
+// Up applies at most steps pending migrations, or all of them when steps is
+// 0, and returns the ones applied.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	out := []*Migration{}
+	err := m.withLock(ctx, func(conn *sql.Conn, done map[int64]*applied) error {
+		for _, mig := range m.migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			if steps > 0 && len(out) == steps {
+				break
+			}
+			if err := execScript(ctx, conn, mig.Up); err != nil {
+				return fmt.Errorf("apply %d_%s: %v", mig.Version, mig.Name, err)
+			}
+			_, err := conn.ExecContext(ctx, fmt.Sprintf("INSERT INTO `%s` (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)", m.table),
+				mig.Version, mig.Name, mig.Checksum, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("record %d_%s: %v", mig.Version, mig.Name, err)
+			}
+			out = append(out, mig)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Down reverts the last steps applied migrations, or only the last one when
+// steps is 0, and returns the ones reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	out := []*Migration{}
+	err := m.withLock(ctx, func(conn *sql.Conn, done map[int64]*applied) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(out) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := done[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+			}
+			if err := execScript(ctx, conn, mig.Down); err != nil {
+				return fmt.Errorf("revert %d_%s: %v", mig.Version, mig.Name, err)
+			}
+			_, err := conn.ExecContext(ctx, fmt.Sprintf("DELETE FROM `%s` WHERE version = ?", m.table), mig.Version)
+			if err != nil {
+				return fmt.Errorf("unrecord %d_%s: %v", mig.Version, mig.Name, err)
+			}
+			out = append(out, mig)
+		}
+		return nil
+	})
+	return out, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	out := []*Status{}
+	err := m.withLock(ctx, func(conn *sql.Conn, done map[int64]*applied) error {
+		for _, mig := range m.migrations {
+			st := &Status{Migration: mig}
+			if a, ok := done[mig.Version]; ok {
+				appliedAt := a.AppliedAt
+				st.AppliedAt = &appliedAt
+			}
+			out = append(out, st)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Latest returns the version of the newest known migration, 0 when there is
+// none.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the highest applied version. Unlike Status it takes no lock
+// and creates nothing, so it is cheap enough for health checks.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version sql.NullInt64
+	err := m.db.QueryRowContext(ctx, fmt.Sprintf("SELECT MAX(version) FROM `%s`", m.table)).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %v", m.table, err)
+	}
+	return version.Int64, nil
+}
+
+// withLock runs fn on a single connection holding a named lock, so several
+// instances starting together don't apply the same migration twice. fn gets
+// the applied versions after their checksums have been verified.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, done map[int64]*applied) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	lockName := "migrate:" + m.table
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeoutSeconds).Scan(&locked); err != nil {
+		return fmt.Errorf("acquire lock: %v", err)
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("acquire lock: timed out waiting for %s", lockName)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` ("+
+		"`version` bigint NOT NULL, "+
+		"`name` varchar(255) NOT NULL, "+
+		"`checksum` char(64) NOT NULL, "+
+		"`applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP, "+
+		"PRIMARY KEY (`version`)"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4", m.table))
+	if err != nil {
+		return fmt.Errorf("create %s: %v", m.table, err)
+	}
+
+	done, err := m.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, done)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]*applied, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT version, checksum, applied_at FROM `%s`", m.table))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", m.table, err)
+	}
+	defer rows.Close()
+
+	done := []*applied{}
+	for rows.Next() {
+		a := &applied{}
+		if err := rows.Scan(&a.Version, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("read %s: %v", m.table, err)
+		}
+		done = append(done, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %v", m.table, err)
+	}
+	return verifyApplied(m.migrations, done)
+}
+
+// verifyApplied maps the applied versions, failing when the file of one is
+// missing or was changed since.
+func verifyApplied(migrations []*Migration, done []*applied) (map[int64]*applied, error) {
+	known := map[int64]*Migration{}
+	for _, mig := range migrations {
+		known[mig.Version] = mig
+	}
+	out := map[int64]*applied{}
+	for _, a := range done {
+		mig, ok := known[a.Version]
+		if !ok {
+			return nil, fmt.Errorf("version %d is applied but its file is missing", a.Version)
+		}
+		if mig.Checksum != a.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s was changed after it was applied", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+		out[a.Version] = a
+	}
+	return out, nil
+}
+
+// execScript runs every statement of script in order. MySQL commits DDL
+// implicitly, so a failing script can leave its earlier statements applied.
+func execScript(ctx context.Context, conn *sql.Conn, script string) error {
+	for i, stmt := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("statement %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func load(t *testing.T, up string) *Migration {
+	t.Helper()
+	migrations, err := Load(fstest.MapFS{
+		"migrations/0001_init.up.sql":   {Data: []byte(up)},
+		"migrations/0001_init.down.sql": {Data: []byte("DROP TABLE a;")},
+	}, "migrations")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	return migrations[0]
+}
+
+func TestVerifyApplied(t *testing.T) {
+	mig := load(t, "CREATE TABLE a (id int);")
+	edited := load(t, "CREATE TABLE a (id bigint);")
+	if mig.Checksum == edited.Checksum {
+		t.Fatal("an edited up file has the same checksum")
+	}
+	if load(t, "CREATE TABLE a (id int);").Checksum != mig.Checksum {
+		t.Fatal("the checksum of the same up file changed")
+	}
+
+	tests := []struct {
+		name     string
+		done     []*applied
+		wantErr  bool
+		mismatch bool
+	}{
+		{"nothing applied", nil, false, false},
+		{"unchanged", []*applied{{Version: 1, Checksum: mig.Checksum}}, false, false},
+		{"edited after it was applied", []*applied{{Version: 1, Checksum: edited.Checksum}}, true, true},
+		{"no checksum recorded", []*applied{{Version: 1}}, true, true},
+		{"file missing", []*applied{{Version: 1, Checksum: mig.Checksum}, {Version: 2, Checksum: mig.Checksum}}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyApplied([]*Migration{mig}, tt.done)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyApplied() error = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrChecksumMismatch) != tt.mismatch {
+				t.Errorf("verifyApplied() error = %v, want checksum mismatch %v", err, tt.mismatch)
+			}
+			if err == nil && len(got) != len(tt.done) {
+				t.Errorf("verifyApplied() = %d versions, want %d", len(got), len(tt.done))
+			}
+		})
+	}
+}
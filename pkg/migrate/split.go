@@ -0,0 +1,56 @@
+package migrate
+
+import "strings"
+
+// splitStatements splits a script on the semicolons that end a statement,
+// ignoring the ones inside quotes, backticks and comments.
+func splitStatements(script string) []string {
+	out := []string{}
+	var b strings.Builder
+	flush := func() {
+		if stmt := strings.TrimSpace(b.String()); stmt != "" {
+			out = append(out, stmt)
+		}
+		b.Reset()
+	}
+
+	var quote byte
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case quote != 0:
+			b.WriteByte(ch)
+			if ch == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				b.WriteByte(script[i])
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			b.WriteByte(ch)
+		case ch == '-' && strings.HasPrefix(script[i:], "-- "), ch == '#':
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end
+			}
+			b.WriteByte('\n')
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			b.WriteByte(' ')
+		case ch == ';':
+			flush()
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	flush()
+	return out
+}
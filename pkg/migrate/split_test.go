@@ -0,0 +1,40 @@
+package migrate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{"statements", "CREATE TABLE a (id int);\nCREATE TABLE b (id int);\n", []string{"CREATE TABLE a (id int)", "CREATE TABLE b (id int)"}},
+		{"no trailing semicolon", "SELECT 1;\nSELECT 2", []string{"SELECT 1", "SELECT 2"}},
+		{"empty statements", ";\n ;SELECT 1;;", []string{"SELECT 1"}},
+		{"semicolon in single quotes", "INSERT INTO a VALUES ('x;y');SELECT 1", []string{"INSERT INTO a VALUES ('x;y')", "SELECT 1"}},
+		{"semicolon in double quotes", `INSERT INTO a VALUES ("x;y");SELECT 1`, []string{`INSERT INTO a VALUES ("x;y")`, "SELECT 1"}},
+		{"semicolon in backticks", "CREATE TABLE `a;b` (id int);SELECT 1", []string{"CREATE TABLE `a;b` (id int)", "SELECT 1"}},
+		{"escaped quote", `INSERT INTO a VALUES ('it\'s;');SELECT 1`, []string{`INSERT INTO a VALUES ('it\'s;')`, "SELECT 1"}},
+		{"doubled quote", "INSERT INTO a VALUES ('it''s;');SELECT 1", []string{"INSERT INTO a VALUES ('it''s;')", "SELECT 1"}},
+		{"dash comment", "-- drop it; really\nDROP TABLE a;", []string{"DROP TABLE a"}},
+		{"dash comment after a statement", "SELECT 1; -- one; two\nSELECT 2;", []string{"SELECT 1", "SELECT 2"}},
+		{"dash comment at the end", "SELECT 1;\n-- done;", []string{"SELECT 1"}},
+		{"hash comment", "# one; two\nSELECT 1;", []string{"SELECT 1"}},
+		{"double dash without space", "SELECT 1--1;", []string{"SELECT 1--1"}},
+		{"block comment", "SELECT /* a; b */ 1;SELECT 2", []string{"SELECT   1", "SELECT 2"}},
+		{"multi-line block comment", "/*\n one;\n two;\n*/\nSELECT 1;", []string{"SELECT 1"}},
+		{"unterminated block comment", "SELECT 1; /* a;", []string{"SELECT 1"}},
+		{"comment markers in quotes", "INSERT INTO a VALUES ('-- x; /* y */');", []string{"INSERT INTO a VALUES ('-- x; /* y */')"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitStatements(tt.script)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements(%q) = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
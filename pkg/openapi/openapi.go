@@ -0,0 +1,297 @@
+// Package openapi builds an OpenAPI 3 document from a table of routes whose
+// schemas are generated from the very types the handlers bind and answer, so
+// the document can't drift from the code.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+// Route documents one route. Query and Body are zero values of the types the
+// handler binds, Data the one it answers in the data field of the envelope.
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	// Auth requires a bearer token, Roles further restricts it.
+	Auth  bool
+	Roles []string
+	Query any
+	Body  any
+	// BodyTypes replaces the JSON body, e.g. uploads, with a schema per
+	// content type.
+	BodyTypes map[string]*Schema
+	Data      any
+	// Produces lists the non JSON content types answered, e.g. files. A
+	// route with neither Data nor Produces answers the bare envelope.
+	Produces []string
+	// PathEnums restricts the values of path parameters.
+	PathEnums map[string][]string
+	// Conditional answers ETag and Last-Modified and takes the conditional
+	// request headers, answering 304 when the client's copy is current.
+	Conditional bool
+	// Errors lists the statuses of the expected failures, the ones implied by
+	// the route, e.g. 401 for Auth, are added.
+	Errors []int
+}
+
+const errorResponse = "Error"
+
+// New builds the document of routes. envelope is the type wrapping every
+// JSON answer, its field named dataField is documented per route.
+func New(info Info, envelope any, dataField string, routes []Route) *Document {
+	d := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]map[string]*Operation{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	g := newGenerator(d.Components.Schemas)
+	envelopeRef := g.schema(reflect.TypeOf(envelope))
+	d.Components.Responses = map[string]*Response{
+		errorResponse: {
+			Description: "The request failed, meta carries the error code and message.",
+			Content:     map[string]*MediaType{"application/json": {Schema: envelopeRef}},
+		},
+	}
+	for _, r := range routes {
+		path, params := pathParams(r.Path, r.PathEnums)
+		if d.Paths[path] == nil {
+			d.Paths[path] = map[string]*Operation{}
+		}
+		op := &Operation{
+			Summary:     r.Summary,
+			Description: r.Description,
+			OperationID: operationID(r.Method, r.Path),
+			Parameters:  params,
+			Responses:   map[string]*Response{},
+		}
+		if r.Tag != "" {
+			op.Tags = []string{r.Tag}
+		}
+		if r.Auth {
+			op.Security = []map[string][]string{{"bearerAuth": {}}}
+			if len(r.Roles) > 0 {
+				op.Description = strings.TrimSpace(op.Description + "\n\nRoles: " + strings.Join(r.Roles, ", ") + ".")
+			}
+		}
+		if r.Query != nil {
+			op.Parameters = append(op.Parameters, g.queryParams(reflect.TypeOf(r.Query))...)
+		}
+		switch {
+		case r.BodyTypes != nil:
+			op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{}}
+			for ct, s := range r.BodyTypes {
+				op.RequestBody.Content[ct] = &MediaType{Schema: s}
+			}
+		case r.Body != nil:
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(r.Body))}},
+			}
+		}
+
+		ok := &Response{Description: "OK", Content: map[string]*MediaType{}}
+		switch {
+		case r.Data != nil:
+			ok.Content["application/json"] = &MediaType{Schema: g.envelope(reflect.TypeOf(envelope), dataField, reflect.TypeOf(r.Data))}
+		case len(r.Produces) == 0:
+			ok.Content["application/json"] = &MediaType{Schema: envelopeRef}
+		}
+		for _, ct := range r.Produces {
+			s := &Schema{Type: "string"}
+			if !strings.HasPrefix(ct, "text/") {
+				s.Format = "binary"
+			}
+			ok.Content[ct] = &MediaType{Schema: s}
+		}
+		op.Responses["200"] = ok
+		if r.Conditional {
+			op.Parameters = append(op.Parameters,
+				&Parameter{Name: "If-None-Match", In: "header", Description: "ETag of the copy held by the client.", Schema: &Schema{Type: "string"}},
+				&Parameter{Name: "If-Modified-Since", In: "header", Description: "Last-Modified date of the copy held by the client.", Schema: &Schema{Type: "string"}},
+			)
+			op.Responses["304"] = &Response{Description: "Not Modified, the copy held by the client is current."}
+		}
+		for _, status := range errorStatuses(r) {
+			op.Responses[fmt.Sprint(status)] = &Response{Ref: "#/components/responses/" + errorResponse}
+		}
+		d.Paths[path][strings.ToLower(r.Method)] = op
+	}
+	return d
+}
+
+func errorStatuses(r Route) []int {
+	statuses := map[int]bool{http.StatusInternalServerError: true}
+	for _, s := range r.Errors {
+		statuses[s] = true
+	}
+	if r.Query != nil || r.Body != nil || r.BodyTypes != nil {
+		statuses[http.StatusBadRequest] = true
+	}
+	if r.Auth {
+		statuses[http.StatusUnauthorized] = true
+	}
+	if len(r.Roles) > 0 {
+		statuses[http.StatusForbidden] = true
+	}
+	out := make([]int, 0, len(statuses))
+	for s := range statuses {
+		out = append(out, s)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// pathParams turns the gin parameters of path, e.g. :id, into OpenAPI ones.
+// Parameters named id or ending with Id are integers.
+func pathParams(path string, enums map[string][]string) (string, []*Parameter) {
+	var params []*Parameter
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if !strings.HasPrefix(s, ":") && !strings.HasPrefix(s, "*") {
+			continue
+		}
+		name := s[1:]
+		segments[i] = "{" + name + "}"
+		schema := &Schema{Type: "string", Enum: enums[name]}
+		if name == "id" || strings.HasSuffix(name, "Id") {
+			schema = &Schema{Type: "integer"}
+		}
+		params = append(params, &Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, s := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '.' || r == '-' || r == ':' }) {
+		b.WriteString(strings.ToUpper(s[:1]) + s[1:])
+	}
+	return b.String()
+}
+
+// Check reports the routes served by gin that the document misses and the
+// documented operations no route serves.
+func (d *Document) Check(routes gin.RoutesInfo) error {
+	served := map[string]bool{}
+	var problems []string
+	for _, r := range routes {
+		path, _ := pathParams(r.Path, nil)
+		key := r.Method + " " + path
+		served[key] = true
+		if _, ok := d.Paths[path][strings.ToLower(r.Method)]; !ok {
+			problems = append(problems, "undocumented route "+key)
+		}
+	}
+	for path, ops := range d.Paths {
+		for method := range ops {
+			if key := strings.ToUpper(method) + " " + path; !served[key] {
+				problems = append(problems, "documented route not served "+key)
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("openapi document out of sync: %s", strings.Join(problems, ", "))
+}
@@ -0,0 +1,252 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator turns Go types into schemas, registering the named structs as
+// components so they are described once.
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator(schemas map[string]*Schema) *generator {
+	return &generator{schemas: schemas, names: map[reflect.Type]string{}}
+}
+
+// schema returns the schema of t, a reference for named structs.
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	case t.Kind() == reflect.Struct:
+		return g.object(t)
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	}
+	// interface{} and the like, anything goes.
+	return &Schema{}
+}
+
+// component registers the named struct t and returns its name, qualified by
+// its package when another type already took the plain one.
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	g.names[t] = name
+	// Registered before its fields so recursive types end.
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(t)
+	return name
+}
+
+// object describes the exported fields of the struct t by their JSON name.
+func (g *generator) object(t reflect.Type) *Schema {
+	out := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, omitempty := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := g.object(indirect(f.Type))
+			for k, v := range embedded.Properties {
+				out.Properties[k] = v
+			}
+			out.Required = append(out.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s, required := g.field(f)
+		out.Properties[name] = s
+		if required && !omitempty {
+			out.Required = append(out.Required, name)
+		}
+	}
+	return out
+}
+
+// field returns the schema of a field constrained by its binding tag and
+// whether the tag requires it.
+func (g *generator) field(f reflect.StructField) (*Schema, bool) {
+	s := g.schema(f.Type)
+	if f.Type == timeType || indirect(f.Type) == timeType {
+		s = timeSchema(f.Tag.Get("time_format"))
+	}
+	if s.Ref != "" {
+		// Constraints can't sit next to a reference.
+		return s, hasRule(f.Tag.Get("binding"), "required")
+	}
+	return s, applyRules(s, indirect(f.Type).Kind(), f.Tag.Get("binding"))
+}
+
+// queryParams describes the query parameters bound into the struct t by
+// their form tag.
+func (g *generator) queryParams(t reflect.Type) []*Parameter {
+	t = indirect(t)
+	var out []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.SplitN(f.Tag.Get("form"), ",", 2)[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		s, required := g.field(f)
+		out = append(out, &Parameter{Name: name, In: "query", Required: required, Schema: s})
+	}
+	return out
+}
+
+// envelope describes the wrapper type with its field dataField of type data.
+func (g *generator) envelope(wrapper reflect.Type, dataField string, data reflect.Type) *Schema {
+	s := g.object(indirect(wrapper))
+	s.Properties[dataField] = g.schema(data)
+	return s
+}
+
+func timeSchema(layout string) *Schema {
+	switch layout {
+	case "":
+		return &Schema{Type: "string", Format: "date-time"}
+	case "2006-01-02":
+		return &Schema{Type: "string", Format: "date"}
+	case "2006-01":
+		return &Schema{Type: "string", Pattern: `^\d{4}-\d{2}$`}
+	}
+	return &Schema{Type: "string"}
+}
+
+// applyRules translates the validator rules of a binding tag into schema
+// constraints and reports whether the field is required. The rules after
+// dive apply to the elements and are left out.
+func applyRules(s *Schema, kind reflect.Kind, tag string) bool {
+	var required bool
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "date":
+			s.Format = "date"
+		case "month":
+			s.Pattern = `^\d{4}-\d{2}$`
+		case "password":
+			s.Format = "password"
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "each_oneof":
+			s.Description = "Comma separated values among " + strings.Join(strings.Fields(param), ", ") + "."
+		case "min", "gte", "gt":
+			setMin(s, kind, param, name == "gt")
+		case "max", "lte", "lt":
+			setMax(s, kind, param, name == "lt")
+		}
+	}
+	return required
+}
+
+func setMin(s *Schema, kind reflect.Kind, param string, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch kind {
+	case reflect.String:
+		s.MinLength = intPtr(n)
+	case reflect.Slice, reflect.Array:
+		s.MinItems = intPtr(n)
+	case reflect.Map:
+		s.MinProperties = intPtr(n)
+	default:
+		s.Minimum, s.ExclusiveMinimum = &n, exclusive
+	}
+}
+
+func setMax(s *Schema, kind reflect.Kind, param string, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch kind {
+	case reflect.String:
+		s.MaxLength = intPtr(n)
+	case reflect.Slice, reflect.Array:
+		s.MaxItems = intPtr(n)
+	case reflect.Map:
+	default:
+		s.Maximum, s.ExclusiveMaximum = &n, exclusive
+	}
+}
+
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == "dive" {
+			return false
+		}
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonName(f reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name, strings.Contains(opts, "omitempty")
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func intPtr(n float64) *int {
+	i := int(n)
+	return &i
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+type gormSpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// GormPlugin records a client span for every statement run through gorm, as
+// a child of the span carried by the statement context. The statement is
+// recorded without its bound values so no user data ends up in the traces.
+type GormPlugin struct {
+	DBName string
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	hooks := []struct {
+		name      string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+		operation string
+	}{
+		{"gorm:create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register, "insert"},
+		{"gorm:query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register, "select"},
+		{"gorm:update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register, "update"},
+		{"gorm:delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register, "delete"},
+		{"gorm:row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register, "select"},
+		{"gorm:raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register, "raw"},
+	}
+	for _, h := range hooks {
+		if err := h.before("tracing:before_"+h.name, p.before(h.operation)); err != nil {
+			return err
+		}
+		if err := h.after("tracing:after_"+h.name, p.after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Startup and CLI statements are not part of any trace.
+			return
+		}
+		name := "db." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		spanCtx, span := Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemMySQL, semconv.DBName(p.DBName), semconv.DBOperation(operation)),
+		)
+		db.Statement.Context = spanCtx
+		db.InstanceSet(gormSpanKey, &gormSpan{span: span, parent: ctx})
+	}
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	gs := v.(*gormSpan)
+	span := gs.span
+	defer span.End()
+	// A statement reused for another query must not nest under this span.
+	db.Statement.Context = gs.parent
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBSQLTable(db.Statement.Table))
+	}
+	if stmt := strings.TrimSpace(db.Statement.SQL.String()); stmt != "" {
+		span.SetAttributes(semconv.DBStatement(stmt))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+)
+
+// Codes of the field violations, sent to clients in meta.details. They are
+// part of the API contract and must never change.
+const (
+	CodeRequired      = "REQUIRED"
+	CodeInvalid       = "INVALID"
+	CodeInvalidType   = "INVALID_TYPE"
+	CodeInvalidEmail  = "INVALID_EMAIL"
+	CodeInvalidDate   = "INVALID_DATE"
+	CodeInvalidMonth  = "INVALID_MONTH"
+	CodeInvalidOption = "INVALID_OPTION"
+	CodeWeakPassword  = "WEAK_PASSWORD"
+	CodeTooShort      = "TOO_SHORT"
+	CodeTooLong       = "TOO_LONG"
+	CodeTooSmall      = "TOO_SMALL"
+	CodeTooLarge      = "TOO_LARGE"
+)
+
+// codes maps the rules to the code of their violation. The rules whose
+// meaning depends on the kind of the field, e.g. min on a string or on a
+// number, have a ".string" variant. Their messages are in the i18n catalog.
+var codes = map[string]string{
+	"required":   CodeRequired,
+	"email":      CodeInvalidEmail,
+	"date":       CodeInvalidDate,
+	"month":      CodeInvalidMonth,
+	"password":   CodeWeakPassword,
+	"oneof":      CodeInvalidOption,
+	"each_oneof": CodeInvalidOption,
+	"min":        CodeTooSmall,
+	"gte":        CodeTooSmall,
+	"gt":         CodeTooSmall,
+	"max":        CodeTooLarge,
+	"lte":        CodeTooLarge,
+	"lt":         CodeTooLarge,
+	"min.string": CodeTooShort,
+	"max.string": CodeTooLong,
+	"number":     CodeInvalidType,
+	"boolean":    CodeInvalidType,
+	"type":       CodeInvalidType,
+	"invalid":    CodeInvalid,
+}
+
+// lookup returns the code and the message in locale of a violation of rule
+// by the field name of the given kind.
+func lookup(locale, rule, param, name string, kind reflect.Kind) (string, string) {
+	if _, ok := codes[rule+".string"]; ok && kind == reflect.String {
+		rule += ".string"
+	} else if _, ok := codes[rule]; !ok {
+		rule = "invalid"
+	}
+	if strings.HasSuffix(rule, "oneof") {
+		param = strings.Join(strings.Fields(param), ", ")
+	}
+	return codes[rule], i18n.T(locale, i18n.ValidationKey(rule), name, param)
+}
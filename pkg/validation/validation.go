@@ -0,0 +1,225 @@
+// Package validation binds request payloads and checks them against the rules
+// declared in their binding tags, reporting every violation at once.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/i18n"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	monthLayout       = "2006-01"
+	passwordMinLength = 8
+)
+
+var setupOnce sync.Once
+
+// setup registers the custom rules on gin's validator and makes it name the
+// fields after their JSON or query key.
+func setup() {
+	setupOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(fieldName)
+		rules := map[string]validator.Func{
+			"date":       layout(dateLayout),
+			"month":      layout(monthLayout),
+			"password":   password,
+			"each_oneof": eachOneOf,
+		}
+		for tag, fn := range rules {
+			if err := v.RegisterValidation(tag, fn); err != nil {
+				panic(fmt.Sprintf("register %s rule: %v", tag, err))
+			}
+		}
+	})
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(f.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// layout checks that a string field is a time in the given layout.
+func layout(l string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		_, err := time.Parse(l, fl.Field().String())
+		return err == nil
+	}
+}
+
+// password checks that a string field is long enough and mixes letters and
+// digits.
+func password(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	if utf8.RuneCountInString(s) < passwordMinLength {
+		return false
+	}
+	var letter, digit bool
+	for _, r := range s {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	return letter && digit
+}
+
+// eachOneOf checks that every comma separated value of a string field is one
+// of the space separated values of the param.
+func eachOneOf(fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+	for _, v := range strings.Split(fl.Field().String(), ",") {
+		ok := false
+		for _, a := range allowed {
+			ok = ok || strings.TrimSpace(v) == a
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// BindJSON decodes the JSON body into obj and validates it.
+func BindJSON(c *gin.Context, obj any) error {
+	setup()
+	return toError(i18n.FromContext(c.Request.Context()), c.ShouldBindJSON(obj))
+}
+
+// BindQuery decodes the query string into obj, a pointer to a flat struct
+// with form tags, and validates it. Time fields are parsed with their
+// time_format tag.
+func BindQuery(c *gin.Context, obj any) error {
+	setup()
+	locale := i18n.FromContext(c.Request.Context())
+	// gin stops at the first value it can't parse without naming the key, so
+	// the types are checked here first.
+	if details := queryTypeDetails(locale, obj, c.Request.URL.Query()); len(details) > 0 {
+		return failed(details)
+	}
+	return toError(locale, c.ShouldBindQuery(obj))
+}
+
+func toError(locale string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		details := make([]errutil.Detail, 0, len(verrs))
+		for _, fe := range verrs {
+			details = append(details, newDetail(locale, path(fe.Namespace()), fe.Field(), fe.Tag(), fe.Param(), fe.Kind()))
+		}
+		return failed(details)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		name := typeErr.Field[strings.LastIndex(typeErr.Field, ".")+1:]
+		return failed([]errutil.Detail{newDetail(locale, typeErr.Field, name, "type", "", typeErr.Type.Kind())})
+	}
+	return errutil.New(errutil.ErrGeneralBadRequest, err)
+}
+
+// path strips the name of the top level struct from a validator namespace,
+// e.g. HTTPLogin.data.email becomes data.email.
+func path(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func newDetail(locale, field, name, rule, param string, kind reflect.Kind) errutil.Detail {
+	code, msg := lookup(locale, rule, param, name, kind)
+	return errutil.Detail{
+		Field:   field,
+		Code:    code,
+		Message: msg,
+	}
+}
+
+func failed(details []errutil.Detail) error {
+	fields := make([]string, len(details))
+	for i, d := range details {
+		fields[i] = d.Field
+	}
+	err := errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeValidationFailed, fmt.Errorf("invalid fields: %s", strings.Join(fields, ", ")))
+	return errutil.WithDetails(err, details...)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// queryTypeDetails reports the query values that can't be parsed into the
+// type of their field.
+func queryTypeDetails(locale string, obj any, query map[string][]string) []errutil.Detail {
+	var details []errutil.Detail
+	t := reflect.TypeOf(obj).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("form")
+		if name == "" || len(query[name]) == 0 || query[name][0] == "" {
+			continue
+		}
+		if rule := parseRule(f, query[name][0]); rule != "" {
+			details = append(details, newDetail(locale, name, name, rule, "", f.Type.Kind()))
+		}
+	}
+	return details
+}
+
+// parseRule returns the rule violated by a value that doesn't parse into the
+// field, or an empty string.
+func parseRule(f reflect.StructField, value string) string {
+	if f.Type == timeType {
+		l := f.Tag.Get("time_format")
+		if _, err := time.Parse(l, value); err != nil {
+			if l == monthLayout {
+				return "month"
+			}
+			return "date"
+		}
+		return ""
+	}
+	var err error
+	rule := "number"
+	switch f.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(value, 10, f.Type.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(value, 10, f.Type.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(value, f.Type.Bits())
+	case reflect.Bool:
+		_, err = strconv.ParseBool(value)
+		rule = "boolean"
+	}
+	if err != nil {
+		return rule
+	}
+	return ""
+}
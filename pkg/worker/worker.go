@@ -0,0 +1,70 @@
+// Package worker runs fire-and-forget tasks outside of the request that
+// started them while letting the process wait for them before exiting.
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	closed bool
+}
+
+func New() *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go runs fn in the background. fn gets a context that is only canceled when
+// Shutdown gives up waiting. It reports false, without running fn, once the
+// group is shutting down.
+func (g *Group) Go(fn func(ctx context.Context)) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return false
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn(g.ctx)
+	}()
+	return true
+}
+
+// Running reports whether the group still accepts tasks.
+func (g *Group) Running() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.closed
+}
+
+// Shutdown stops accepting tasks and waits for the running ones. When ctx is
+// done first their context is canceled and ctx's error is returned.
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		g.cancel()
+		return nil
+	case <-ctx.Done():
+		g.cancel()
+		return ctx.Err()
+	}
+}
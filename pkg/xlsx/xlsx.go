@@ -0,0 +1,131 @@
+// Package xlsx streams a single-sheet Office Open XML workbook row by row, so
+// large exports never have to be held in memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	sheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetFooter = `</sheetData></worksheet>`
+
+	ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+type Writer struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+// NewWriter writes the workbook parts and opens the sheet, rows can then be
+// appended with Write. Close must be called to finish the document.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, escape(sheetName))},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, sheetHeader); err != nil {
+		return nil, err
+	}
+	return &Writer{zw: zw, sheet: sheet}, nil
+}
+
+// Write appends a row, values that parse as numbers are stored as numeric
+// cells and everything else as inline strings.
+func (w *Writer) Write(record []string) error {
+	w.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, w.row)
+	for i, v := range record {
+		ref := columnName(i) + strconv.Itoa(w.row)
+		if isNumber(v) {
+			fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, v)
+			continue
+		}
+		fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(v))
+	}
+	b.WriteString("</row>")
+	_, err := io.WriteString(w.sheet, b.String())
+	return err
+}
+
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.sheet, sheetFooter); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// isNumber only accepts plain decimal notation, so values such as "Inf" or
+// "1e5" stay text.
+func isNumber(v string) bool {
+	if v == "" || strings.ContainsAny(strings.ToLower(v), "abcdefghijklmnopqrstuvwxyz") {
+		return false
+	}
+	_, err := strconv.ParseFloat(v, 64)
+	return err == nil
+}
+
+// columnName converts a zero based column index into its letter, e.g. 0 is A
+// and 27 is AB.
+func columnName(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
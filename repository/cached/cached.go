@@ -0,0 +1,225 @@
+// Package cached decorates a Repository with a cache of the venue reference
+// data: cities, venues and their galleries, categories, packages, addons and
+// translations. Any write to that data drops the whole cache by moving to a
+// new generation of keys, the old ones expire on their own.
+package cached
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	repoInterface "github.com/faruqfadhil/venue-api/core/repository"
+	"github.com/faruqfadhil/venue-api/pkg/cache"
+	"github.com/faruqfadhil/venue-api/pkg/logger"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+)
+
+const (
+	keyPrefix     = "venue-api:"
+	generationKey = keyPrefix + "generation"
+)
+
+type repository struct {
+	// The methods not overridden here go straight to the database.
+	repoInterface.Repository
+	cache   cache.Cache
+	ttl     time.Duration
+	metrics *metrics.Metrics
+}
+
+// New caches the reads of next in c for ttl. The cache is best effort, when
+// it fails the reads go to next and the failure is logged.
+func New(next repoInterface.Repository, c cache.Cache, ttl time.Duration, m *metrics.Metrics) repoInterface.Repository {
+	return &repository{
+		Repository: next,
+		cache:      c,
+		ttl:        ttl,
+		metrics:    m,
+	}
+}
+
+// entry wraps the cached values, gob can't encode some of them, e.g. nil
+// pointers, at the top level.
+type entry[T any] struct {
+	Value T
+}
+
+// read returns the value cached under name or loads it with load and caches
+// it. method labels the lookup in the metrics.
+func read[T any](ctx context.Context, r *repository, method, name string, load func() (T, error)) (T, error) {
+	log := logger.FromContext(ctx)
+	key, err := r.key(ctx, name)
+	if err != nil {
+		log.WarnContext(ctx, "cache lookup failed", slog.String("method", method), slog.String("error", err.Error()))
+	} else if value, ok, err := get[T](ctx, r.cache, key); err != nil {
+		log.WarnContext(ctx, "cache lookup failed", slog.String("method", method), slog.String("error", err.Error()))
+	} else if ok {
+		r.metrics.ObserveCache(method, true)
+		return value, nil
+	}
+	r.metrics.ObserveCache(method, false)
+
+	value, loadErr := load()
+	if loadErr != nil || key == "" {
+		return value, loadErr
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry[T]{Value: value}); err != nil {
+		log.WarnContext(ctx, "cache store failed", slog.String("method", method), slog.String("error", err.Error()))
+		return value, nil
+	}
+	if err := r.cache.Set(ctx, key, buf.Bytes(), r.ttl); err != nil {
+		log.WarnContext(ctx, "cache store failed", slog.String("method", method), slog.String("error", err.Error()))
+	}
+	return value, nil
+}
+
+func get[T any](ctx context.Context, c cache.Cache, key string) (T, bool, error) {
+	var out entry[T]
+	b, ok, err := c.Get(ctx, key)
+	if err != nil || !ok {
+		return out.Value, false, err
+	}
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&out); err != nil {
+		return out.Value, false, fmt.Errorf("decode %s: %v", key, err)
+	}
+	// gob doesn't tell empty slices and maps from nil ones, the repository
+	// answers empty ones.
+	v := reflect.ValueOf(&out.Value).Elem()
+	switch {
+	case v.Kind() == reflect.Slice && v.IsNil():
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	case v.Kind() == reflect.Map && v.IsNil():
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	return out.Value, true, nil
+}
+
+// key returns the key of name in the current generation. The generation can
+// be evicted like any key, a missing one starts a new generation instead of
+// falling back to a value whose entries may predate the last write.
+func (r *repository) key(ctx context.Context, name string) (string, error) {
+	generation, ok, err := r.cache.Get(ctx, generationKey)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		generation = newGeneration()
+		if err := r.cache.Set(ctx, generationKey, generation, 0); err != nil {
+			return "", err
+		}
+	}
+	return keyPrefix + string(generation) + ":" + name, nil
+}
+
+// newGeneration returns the current unix time in nanoseconds, so a generation
+// is never reused even when the previous one was lost.
+func newGeneration() []byte {
+	return []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+}
+
+// invalidate starts a new generation of keys once the data is written. When
+// it fails the cached reads stay stale for up to the ttl.
+func (r *repository) invalidate(ctx context.Context, method string) {
+	if err := r.cache.Set(ctx, generationKey, newGeneration(), 0); err != nil {
+		logger.FromContext(ctx).ErrorContext(ctx, "cache invalidation failed, reads may be stale until the entries expire",
+			slog.String("method", method), slog.String("error", err.Error()))
+	}
+}
+
+func (r *repository) GetCities(ctx context.Context) ([]*entity.City, error) {
+	return read(ctx, r, "GetCities", "cities", func() ([]*entity.City, error) {
+		return r.Repository.GetCities(ctx)
+	})
+}
+
+type venues struct {
+	Venues     []*entity.Venue
+	Pagination *entity.Pagination
+}
+
+// GetVenues only caches the lookups of a single venue, the lists vary too
+// much to be worth it.
+func (r *repository) GetVenues(ctx context.Context, param entity.GetVenuesParam) ([]*entity.Venue, *entity.Pagination, error) {
+	if !singleVenue(param) {
+		return r.Repository.GetVenues(ctx, param)
+	}
+	out, err := read(ctx, r, "GetVenues", fmt.Sprintf("venue:%d:%t", param.ID, param.IsWithoutPagination), func() (venues, error) {
+		vns, pag, err := r.Repository.GetVenues(ctx, param)
+		return venues{Venues: vns, Pagination: pag}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if out.Venues == nil {
+		out.Venues = []*entity.Venue{}
+	}
+	return out.Venues, out.Pagination, nil
+}
+
+func singleVenue(p entity.GetVenuesParam) bool {
+	return p.ID > 0 && len(p.IDs) == 0 && p.CityID == 0 && len(p.CityIDs) == 0 && !p.IsFavourite &&
+		p.Date.IsZero() && p.Page <= 1 && p.Limit == 0 && len(p.NotInIDs) == 0 &&
+		p.Sort == "" && p.Cursor == "" && !p.SkipCount && len(p.Fields) == 0
+}
+
+func (r *repository) GetGalleriesByVenueIDs(ctx context.Context, IDs []int) (map[int][]string, error) {
+	return read(ctx, r, "GetGalleriesByVenueIDs", fmt.Sprintf("galleries:%v", IDs), func() (map[int][]string, error) {
+		return r.Repository.GetGalleriesByVenueIDs(ctx, IDs)
+	})
+}
+
+func (r *repository) GetVenueCategoryPackageByQuery(ctx context.Context, param *entity.GetVenueCategoryByQuery) ([]*entity.VenuePackageCategory, error) {
+	return read(ctx, r, "GetVenueCategoryPackageByQuery", fmt.Sprintf("categories:%+v", *param), func() ([]*entity.VenuePackageCategory, error) {
+		return r.Repository.GetVenueCategoryPackageByQuery(ctx, param)
+	})
+}
+
+func (r *repository) GetVenuePackageByQuery(ctx context.Context, param *entity.GetVenuePackageQuery) ([]*entity.VenuePackage, error) {
+	return read(ctx, r, "GetVenuePackageByQuery", fmt.Sprintf("packages:%+v", *param), func() ([]*entity.VenuePackage, error) {
+		return r.Repository.GetVenuePackageByQuery(ctx, param)
+	})
+}
+
+func (r *repository) GetPackageAddonsByQuery(ctx context.Context, param *entity.GetPackageAddonQuery) ([]*entity.PackageAddon, error) {
+	return read(ctx, r, "GetPackageAddonsByQuery", fmt.Sprintf("addons:%+v", *param), func() ([]*entity.PackageAddon, error) {
+		return r.Repository.GetPackageAddonsByQuery(ctx, param)
+	})
+}
+
+func (r *repository) GetTranslationsByQuery(ctx context.Context, param *entity.GetTranslationQuery) ([]*entity.Translation, error) {
+	return read(ctx, r, "GetTranslationsByQuery", fmt.Sprintf("translations:%+v", *param), func() ([]*entity.Translation, error) {
+		return r.Repository.GetTranslationsByQuery(ctx, param)
+	})
+}
+
+func (r *repository) UpdateVenueCalendarToken(ctx context.Context, venueID int, token string) error {
+	if err := r.Repository.UpdateVenueCalendarToken(ctx, venueID, token); err != nil {
+		return err
+	}
+	r.invalidate(ctx, "UpdateVenueCalendarToken")
+	return nil
+}
+
+func (r *repository) SaveTranslations(ctx context.Context, translations []*entity.Translation) error {
+	if err := r.Repository.SaveTranslations(ctx, translations); err != nil {
+		return err
+	}
+	r.invalidate(ctx, "SaveTranslations")
+	return nil
+}
+
+func (r *repository) ImportVenues(ctx context.Context, data *entity.VenueImport, param *entity.ImportParam) (*entity.ImportResult, error) {
+	out, err := r.Repository.ImportVenues(ctx, data, param)
+	if err == nil && !param.DryRun {
+		r.invalidate(ctx, "ImportVenues")
+	}
+	return out, err
+}
@@ -0,0 +1,92 @@
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	repoInterface "github.com/faruqfadhil/venue-api/core/repository"
+	"github.com/faruqfadhil/venue-api/pkg/cache"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+)
+
+// cityRepo answers the cities it holds and counts the reads.
+type cityRepo struct {
+	repoInterface.Repository
+	cities []*entity.City
+	reads  int
+}
+
+func (r *cityRepo) GetCities(ctx context.Context) ([]*entity.City, error) {
+	r.reads++
+	return r.cities, nil
+}
+
+func (r *cityRepo) UpdateVenueCalendarToken(ctx context.Context, venueID int, token string) error {
+	r.cities = []*entity.City{{ID: 1, Name: "Bandung"}}
+	return nil
+}
+
+// evictingCache loses the generation when told to, as an LRU or Redis under
+// maxmemory may do.
+type evictingCache struct {
+	cache.Cache
+	evictGeneration bool
+}
+
+func (c *evictingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if key == generationKey && c.evictGeneration {
+		c.evictGeneration = false
+		return nil, false, nil
+	}
+	return c.Cache.Get(ctx, key)
+}
+
+func cityNames(t *testing.T, repo repoInterface.Repository) string {
+	t.Helper()
+	cities, err := repo.GetCities(context.Background())
+	if err != nil {
+		t.Fatalf("GetCities: %v", err)
+	}
+	names := ""
+	for _, c := range cities {
+		names += c.Name
+	}
+	return names
+}
+
+func TestInvalidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		evictGeneration bool
+	}{
+		{"generation kept", false},
+		{"generation evicted after the write", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &cityRepo{cities: []*entity.City{{ID: 1, Name: "Bandoeng"}}}
+			c := &evictingCache{Cache: cache.NewMemory(100)}
+			repo := New(next, c, time.Hour, metrics.New())
+
+			if got := cityNames(t, repo); got != "Bandoeng" {
+				t.Fatalf("first read = %q", got)
+			}
+			if got := cityNames(t, repo); got != "Bandoeng" || next.reads != 1 {
+				t.Fatalf("second read = %q after %d reads, want a hit", got, next.reads)
+			}
+
+			if err := repo.UpdateVenueCalendarToken(context.Background(), 1, "token"); err != nil {
+				t.Fatalf("UpdateVenueCalendarToken: %v", err)
+			}
+			c.evictGeneration = tt.evictGeneration
+			if got := cityNames(t, repo); got != "Bandung" {
+				t.Errorf("read after the write = %q, want the new cities", got)
+			}
+			if got := cityNames(t, repo); got != "Bandung" || next.reads != 2 {
+				t.Errorf("read after the write = %q after %d reads, want a hit", got, next.reads)
+			}
+		})
+	}
+}
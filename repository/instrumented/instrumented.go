@@ -0,0 +1,347 @@
+// Package instrumented decorates a Repository with Prometheus metrics and
+// OpenTelemetry spans.
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	repoInterface "github.com/faruqfadhil/venue-api/core/repository"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/faruqfadhil/venue-api/pkg/metrics"
+	"github.com/faruqfadhil/venue-api/pkg/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type repository struct {
+	next    repoInterface.Repository
+	metrics *metrics.Metrics
+}
+
+// New records the duration and the failures of every call made to next, and
+// wraps each of them in a span.
+func New(next repoInterface.Repository, m *metrics.Metrics) repoInterface.Repository {
+	return &repository{
+		next:    next,
+		metrics: m,
+	}
+}
+
+type call struct {
+	method string
+	start  time.Time
+	span   trace.Span
+}
+
+func (r *repository) start(ctx context.Context, method string) (context.Context, *call) {
+	ctx, span := tracing.Tracer().Start(ctx, "repository."+method)
+	return ctx, &call{
+		method: method,
+		start:  time.Now(),
+		span:   span,
+	}
+}
+
+// end is deferred with the address of the named error so the outcome of the
+// call is known. A record not found or a conflict is an expected answer, not
+// a failure.
+func (r *repository) end(c *call, err *error) {
+	failed := *err != nil && !errutil.IsClientErr(*err)
+	r.metrics.ObserveRepository(c.method, time.Since(c.start), failed)
+	if failed {
+		cause := errutil.GetOriginalErr(*err)
+		c.span.RecordError(cause)
+		c.span.SetStatus(codes.Error, cause.Error())
+	}
+	c.span.End()
+}
+
+func (r *repository) Register(ctx context.Context, payload *entity.User) (err error) {
+	ctx, c := r.start(ctx, "Register")
+	defer r.end(c, &err)
+	return r.next.Register(ctx, payload)
+}
+
+func (r *repository) Login(ctx context.Context, email, password string) (_ *entity.Auth, err error) {
+	ctx, c := r.start(ctx, "Login")
+	defer r.end(c, &err)
+	return r.next.Login(ctx, email, password)
+}
+
+func (r *repository) FindUserByEmail(ctx context.Context, email string) (_ *entity.User, err error) {
+	ctx, c := r.start(ctx, "FindUserByEmail")
+	defer r.end(c, &err)
+	return r.next.FindUserByEmail(ctx, email)
+}
+
+func (r *repository) FindUserByID(ctx context.Context, ID int) (_ *entity.User, err error) {
+	ctx, c := r.start(ctx, "FindUserByID")
+	defer r.end(c, &err)
+	return r.next.FindUserByID(ctx, ID)
+}
+
+func (r *repository) FindUsersByIDs(ctx context.Context, IDs []int) (_ []*entity.User, err error) {
+	ctx, c := r.start(ctx, "FindUsersByIDs")
+	defer r.end(c, &err)
+	return r.next.FindUsersByIDs(ctx, IDs)
+}
+
+func (r *repository) ValidateToken(ctx context.Context, token string) (_ *entity.CredentialClaim, err error) {
+	ctx, c := r.start(ctx, "ValidateToken")
+	defer r.end(c, &err)
+	return r.next.ValidateToken(ctx, token)
+}
+
+func (r *repository) IssueToken(ctx context.Context, user *entity.User) (_ *entity.Auth, err error) {
+	ctx, c := r.start(ctx, "IssueToken")
+	defer r.end(c, &err)
+	return r.next.IssueToken(ctx, user)
+}
+
+func (r *repository) UpdateUserLocale(ctx context.Context, userID int, locale string) (err error) {
+	ctx, c := r.start(ctx, "UpdateUserLocale")
+	defer r.end(c, &err)
+	return r.next.UpdateUserLocale(ctx, userID, locale)
+}
+
+func (r *repository) GetVenues(ctx context.Context, param entity.GetVenuesParam) (_ []*entity.Venue, _ *entity.Pagination, err error) {
+	ctx, c := r.start(ctx, "GetVenues")
+	defer r.end(c, &err)
+	return r.next.GetVenues(ctx, param)
+}
+
+func (r *repository) GetCities(ctx context.Context) (_ []*entity.City, err error) {
+	ctx, c := r.start(ctx, "GetCities")
+	defer r.end(c, &err)
+	return r.next.GetCities(ctx)
+}
+
+func (r *repository) GetOrderByPackageIDAndDate(ctx context.Context, packageID int, date time.Time) (_ *entity.Order, err error) {
+	ctx, c := r.start(ctx, "GetOrderByPackageIDAndDate")
+	defer r.end(c, &err)
+	return r.next.GetOrderByPackageIDAndDate(ctx, packageID, date)
+}
+
+func (r *repository) CreateOrder(ctx context.Context, order *entity.Order) (err error) {
+	ctx, c := r.start(ctx, "CreateOrder")
+	defer r.end(c, &err)
+	return r.next.CreateOrder(ctx, order)
+}
+
+func (r *repository) GetOrderByID(ctx context.Context, ID int) (_ *entity.Order, err error) {
+	ctx, c := r.start(ctx, "GetOrderByID")
+	defer r.end(c, &err)
+	return r.next.GetOrderByID(ctx, ID)
+}
+
+func (r *repository) GetPackageByID(ctx context.Context, ID int) (_ *entity.VenuePackage, err error) {
+	ctx, c := r.start(ctx, "GetPackageByID")
+	defer r.end(c, &err)
+	return r.next.GetPackageByID(ctx, ID)
+}
+
+func (r *repository) GetGalleriesByVenueIDs(ctx context.Context, IDs []int) (_ map[int][]string, err error) {
+	ctx, c := r.start(ctx, "GetGalleriesByVenueIDs")
+	defer r.end(c, &err)
+	return r.next.GetGalleriesByVenueIDs(ctx, IDs)
+}
+
+func (r *repository) GetOrdersByDate(ctx context.Context, date time.Time) (_ []*entity.Order, err error) {
+	ctx, c := r.start(ctx, "GetOrdersByDate")
+	defer r.end(c, &err)
+	return r.next.GetOrdersByDate(ctx, date)
+}
+
+func (r *repository) GetOrdersByQuery(ctx context.Context, param *entity.GetOrderQuery) (_ []*entity.Order, err error) {
+	ctx, c := r.start(ctx, "GetOrdersByQuery")
+	defer r.end(c, &err)
+	return r.next.GetOrdersByQuery(ctx, param)
+}
+
+func (r *repository) UpdateVenueCalendarToken(ctx context.Context, venueID int, token string) (err error) {
+	ctx, c := r.start(ctx, "UpdateVenueCalendarToken")
+	defer r.end(c, &err)
+	return r.next.UpdateVenueCalendarToken(ctx, venueID, token)
+}
+
+func (r *repository) MarkOrderPaid(ctx context.Context, ID int, paidAt time.Time) (err error) {
+	ctx, c := r.start(ctx, "MarkOrderPaid")
+	defer r.end(c, &err)
+	return r.next.MarkOrderPaid(ctx, ID, paidAt)
+}
+
+func (r *repository) GetVenuePackageByQuery(ctx context.Context, param *entity.GetVenuePackageQuery) (_ []*entity.VenuePackage, err error) {
+	ctx, c := r.start(ctx, "GetVenuePackageByQuery")
+	defer r.end(c, &err)
+	return r.next.GetVenuePackageByQuery(ctx, param)
+}
+
+func (r *repository) GetVenueCategoryPackageByQuery(ctx context.Context, param *entity.GetVenueCategoryByQuery) (_ []*entity.VenuePackageCategory, err error) {
+	ctx, c := r.start(ctx, "GetVenueCategoryPackageByQuery")
+	defer r.end(c, &err)
+	return r.next.GetVenueCategoryPackageByQuery(ctx, param)
+}
+
+func (r *repository) GetPackageAddonsByQuery(ctx context.Context, param *entity.GetPackageAddonQuery) (_ []*entity.PackageAddon, err error) {
+	ctx, c := r.start(ctx, "GetPackageAddonsByQuery")
+	defer r.end(c, &err)
+	return r.next.GetPackageAddonsByQuery(ctx, param)
+}
+
+func (r *repository) GetPriceRulesByPackageIDs(ctx context.Context, IDs []int) (_ map[int][]*entity.PriceRule, err error) {
+	ctx, c := r.start(ctx, "GetPriceRulesByPackageIDs")
+	defer r.end(c, &err)
+	return r.next.GetPriceRulesByPackageIDs(ctx, IDs)
+}
+
+func (r *repository) GetPriceRuleByID(ctx context.Context, ID int) (_ *entity.PriceRule, err error) {
+	ctx, c := r.start(ctx, "GetPriceRuleByID")
+	defer r.end(c, &err)
+	return r.next.GetPriceRuleByID(ctx, ID)
+}
+
+func (r *repository) CreatePriceRule(ctx context.Context, rule *entity.PriceRule) (err error) {
+	ctx, c := r.start(ctx, "CreatePriceRule")
+	defer r.end(c, &err)
+	return r.next.CreatePriceRule(ctx, rule)
+}
+
+func (r *repository) UpdatePriceRule(ctx context.Context, rule *entity.PriceRule) (err error) {
+	ctx, c := r.start(ctx, "UpdatePriceRule")
+	defer r.end(c, &err)
+	return r.next.UpdatePriceRule(ctx, rule)
+}
+
+func (r *repository) DeletePriceRule(ctx context.Context, ID int) (err error) {
+	ctx, c := r.start(ctx, "DeletePriceRule")
+	defer r.end(c, &err)
+	return r.next.DeletePriceRule(ctx, ID)
+}
+
+func (r *repository) CreatePromo(ctx context.Context, promo *entity.Promo) (err error) {
+	ctx, c := r.start(ctx, "CreatePromo")
+	defer r.end(c, &err)
+	return r.next.CreatePromo(ctx, promo)
+}
+
+func (r *repository) UpdatePromo(ctx context.Context, promo *entity.Promo) (err error) {
+	ctx, c := r.start(ctx, "UpdatePromo")
+	defer r.end(c, &err)
+	return r.next.UpdatePromo(ctx, promo)
+}
+
+func (r *repository) GetPromoByID(ctx context.Context, ID int) (_ *entity.Promo, err error) {
+	ctx, c := r.start(ctx, "GetPromoByID")
+	defer r.end(c, &err)
+	return r.next.GetPromoByID(ctx, ID)
+}
+
+func (r *repository) GetPromoByCode(ctx context.Context, code string) (_ *entity.Promo, err error) {
+	ctx, c := r.start(ctx, "GetPromoByCode")
+	defer r.end(c, &err)
+	return r.next.GetPromoByCode(ctx, code)
+}
+
+func (r *repository) GetPromos(ctx context.Context, param entity.GetPromosParam) (_ []*entity.Promo, _ *entity.Pagination, err error) {
+	ctx, c := r.start(ctx, "GetPromos")
+	defer r.end(c, &err)
+	return r.next.GetPromos(ctx, param)
+}
+
+func (r *repository) CountPromoUsageByUser(ctx context.Context, promoID, userID int) (_ int, err error) {
+	ctx, c := r.start(ctx, "CountPromoUsageByUser")
+	defer r.end(c, &err)
+	return r.next.CountPromoUsageByUser(ctx, promoID, userID)
+}
+
+func (r *repository) GetVenueIDsByOwnerID(ctx context.Context, userID int) (_ []int, err error) {
+	ctx, c := r.start(ctx, "GetVenueIDsByOwnerID")
+	defer r.end(c, &err)
+	return r.next.GetVenueIDsByOwnerID(ctx, userID)
+}
+
+func (r *repository) AssignVenueOwner(ctx context.Context, venueID, userID int) (err error) {
+	ctx, c := r.start(ctx, "AssignVenueOwner")
+	defer r.end(c, &err)
+	return r.next.AssignVenueOwner(ctx, venueID, userID)
+}
+
+func (r *repository) RemoveVenueOwner(ctx context.Context, venueID, userID int) (err error) {
+	ctx, c := r.start(ctx, "RemoveVenueOwner")
+	defer r.end(c, &err)
+	return r.next.RemoveVenueOwner(ctx, venueID, userID)
+}
+
+func (r *repository) IncrementVenueView(ctx context.Context, venueID int, date time.Time) (err error) {
+	ctx, c := r.start(ctx, "IncrementVenueView")
+	defer r.end(c, &err)
+	return r.next.IncrementVenueView(ctx, venueID, date)
+}
+
+func (r *repository) GetOwnerOrders(ctx context.Context, param *entity.OwnerOrderQuery) (_ []*entity.OwnerOrder, _ *entity.Pagination, err error) {
+	ctx, c := r.start(ctx, "GetOwnerOrders")
+	defer r.end(c, &err)
+	return r.next.GetOwnerOrders(ctx, param)
+}
+
+func (r *repository) GetMonthlyBookingStats(ctx context.Context, param *entity.VenueStatsQuery) (_ []*entity.MonthlyBookingStat, err error) {
+	ctx, c := r.start(ctx, "GetMonthlyBookingStats")
+	defer r.end(c, &err)
+	return r.next.GetMonthlyBookingStats(ctx, param)
+}
+
+func (r *repository) GetTopPackageStats(ctx context.Context, param *entity.VenueStatsQuery, limit int) (_ []*entity.PackageBookingStat, err error) {
+	ctx, c := r.start(ctx, "GetTopPackageStats")
+	defer r.end(c, &err)
+	return r.next.GetTopPackageStats(ctx, param, limit)
+}
+
+func (r *repository) CountVenueViews(ctx context.Context, param *entity.VenueStatsQuery) (_ int, err error) {
+	ctx, c := r.start(ctx, "CountVenueViews")
+	defer r.end(c, &err)
+	return r.next.CountVenueViews(ctx, param)
+}
+
+func (r *repository) StreamBookingReport(ctx context.Context, param *entity.ReportQuery, fn func(row *entity.BookingReportRow) error) (err error) {
+	ctx, c := r.start(ctx, "StreamBookingReport")
+	defer r.end(c, &err)
+	return r.next.StreamBookingReport(ctx, param, fn)
+}
+
+func (r *repository) CountBookingReportGroups(ctx context.Context, param *entity.ReportQuery) (_ int, err error) {
+	ctx, c := r.start(ctx, "CountBookingReportGroups")
+	defer r.end(c, &err)
+	return r.next.CountBookingReportGroups(ctx, param)
+}
+
+func (r *repository) StreamRegistrationReport(ctx context.Context, param *entity.ReportQuery, fn func(row *entity.RegistrationReportRow) error) (err error) {
+	ctx, c := r.start(ctx, "StreamRegistrationReport")
+	defer r.end(c, &err)
+	return r.next.StreamRegistrationReport(ctx, param, fn)
+}
+
+func (r *repository) CountRegistrationReportGroups(ctx context.Context, param *entity.ReportQuery) (_ int, err error) {
+	ctx, c := r.start(ctx, "CountRegistrationReportGroups")
+	defer r.end(c, &err)
+	return r.next.CountRegistrationReportGroups(ctx, param)
+}
+
+func (r *repository) ImportVenues(ctx context.Context, data *entity.VenueImport, param *entity.ImportParam) (_ *entity.ImportResult, err error) {
+	ctx, c := r.start(ctx, "ImportVenues")
+	defer r.end(c, &err)
+	return r.next.ImportVenues(ctx, data, param)
+}
+
+func (r *repository) GetTranslationsByQuery(ctx context.Context, param *entity.GetTranslationQuery) (_ []*entity.Translation, err error) {
+	ctx, c := r.start(ctx, "GetTranslationsByQuery")
+	defer r.end(c, &err)
+	return r.next.GetTranslationsByQuery(ctx, param)
+}
+
+func (r *repository) SaveTranslations(ctx context.Context, translations []*entity.Translation) (err error) {
+	ctx, c := r.start(ctx, "SaveTranslations")
+	defer r.end(c, &err)
+	return r.next.SaveTranslations(ctx, translations)
+}
@@ -0,0 +1,125 @@
+package venue
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+)
+
+// venueSortColumns maps the sort options of the venue list to their column.
+var venueSortColumns = map[string]string{
+	entity.VenueSortID:       "id",
+	entity.VenueSortPrice:    "min_price",
+	entity.VenueSortStar:     "star",
+	entity.VenueSortCapacity: "capacity",
+}
+
+// venueColumns maps the JSON fields of a venue to their column, the fields
+// missing here, e.g. city, are filled by the usecase.
+var venueColumns = map[string]string{
+	"name":         "name",
+	"capacity":     "capacity",
+	"star":         "star",
+	"reviewCount":  "review_count",
+	"thumbnailUrl": "thumbnail_url",
+	"description":  "description",
+	"website":      "website",
+	"phone":        "phone",
+	"email":        "email",
+	"instagram":    "instagram",
+	"address":      "address",
+	"logo":         "logo",
+	"isFavourite":  "is_favourite",
+}
+
+// venueKeyColumns are always selected, the usecase needs them to map the
+// cities and to price the venues on a date.
+var venueKeyColumns = []string{"id", "city_id", "min_price", "max_price", "timezone"}
+
+// venueCursor is the position of the last venue of a page. It is sent to
+// clients as opaque base64 JSON.
+type venueCursor struct {
+	Sort string  `json:"s"`
+	Key  float64 `json:"k"`
+	ID   int     `json:"i"`
+}
+
+// venueSort returns the column and the direction of a sort option, id asc by
+// default.
+func venueSort(sort string) (string, bool) {
+	desc := strings.HasPrefix(sort, "-")
+	if column, ok := venueSortColumns[strings.TrimPrefix(sort, "-")]; ok {
+		return column, desc
+	}
+	return "id", false
+}
+
+func venueSelect(fields []string, sortColumn string) []string {
+	columns := append([]string{sortColumn}, venueKeyColumns...)
+	for _, f := range fields {
+		if c, ok := venueColumns[f]; ok {
+			columns = append(columns, c)
+		}
+	}
+	seen := map[string]bool{}
+	out := []string{}
+	for _, c := range columns {
+		if !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// sortKey returns the value of the sort column of v.
+func (v *Venue) sortKey(column string) float64 {
+	switch column {
+	case "min_price":
+		return v.MinPrice
+	case "star":
+		return v.Star
+	case "capacity":
+		return float64(v.Capacity)
+	}
+	return float64(v.ID)
+}
+
+func encodeVenueCursor(c venueCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeVenueCursor(s, sort string) (*venueCursor, error) {
+	var c venueCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err == nil && c.Sort != sort {
+		err = fmt.Errorf("cursor of sort %q used with %q", c.Sort, sort)
+	}
+	if err != nil {
+		return nil, errutil.WithDetails(errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodeInvalidCursor, fmt.Errorf("[decodeVenueCursor] err: %v", err)),
+			errutil.Detail{Field: "cursor", Code: entity.ErrCodeInvalidCursor})
+	}
+	return &c, nil
+}
+
+// afterVenueCursor restricts qb to the venues after the cursor in the order
+// of the column, the id breaking ties.
+func afterVenueCursor(qb *gorm.DB, c *venueCursor, column string, desc bool) *gorm.DB {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	if column == "id" {
+		return qb.Where("id "+op+" ?", c.ID)
+	}
+	return qb.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op), c.Key, c.Key, c.ID)
+}
@@ -0,0 +1,193 @@
+package venue
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetVenueIDsByOwnerID(ctx context.Context, userID int) ([]int, error) {
+	out := []int{}
+	err := r.db.WithContext(ctx).Table("venue_owner").
+		Where("user_id = ?", userID).
+		Order("venue_id asc").
+		Pluck("venue_id", &out).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetVenueIDsByOwnerID] err: %v", err))
+	}
+	return out, nil
+}
+
+// AssignVenueOwner adds the venue to the ones of the user, a customer becomes
+// an owner and an admin keeps its role.
+func (r *repository) AssignVenueOwner(ctx context.Context, venueID, userID int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("auth").
+			Where("id = ? AND role = ?", userID, entity.RoleCustomer).
+			UpdateColumn("role", entity.RoleOwner).Error
+		if err != nil {
+			return err
+		}
+		return tx.Table("venue_owner").
+			Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{"updated_at": gorm.Expr("CURRENT_TIMESTAMP")}),
+			}).
+			Create(&VenueOwner{VenueID: venueID, UserID: userID}).Error
+	})
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[AssignVenueOwner] err: %v", err))
+	}
+	return nil
+}
+
+// RemoveVenueOwner takes the venue out of the ones of the user, the role of
+// the user is left as is.
+func (r *repository) RemoveVenueOwner(ctx context.Context, venueID, userID int) error {
+	err := r.db.WithContext(ctx).Table("venue_owner").
+		Where("venue_id = ? AND user_id = ?", venueID, userID).
+		Delete(&VenueOwner{}).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[RemoveVenueOwner] err: %v", err))
+	}
+	return nil
+}
+
+func (r *repository) IncrementVenueView(ctx context.Context, venueID int, date time.Time) error {
+	err := r.db.WithContext(ctx).Table("venue_view_stat").
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("views + 1")}),
+		}).
+		Create(map[string]interface{}{
+			"venue_id": venueID,
+			"date":     date,
+			"views":    1,
+		}).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[IncrementVenueView] err: %v", err))
+	}
+	return nil
+}
+
+// venueOrders joins every order with its package and category so it can be
+// filtered and grouped by venue.
+func (r *repository) venueOrders(ctx context.Context, venueIDs []int) *gorm.DB {
+	qb := r.db.WithContext(ctx).Table("`order` o").
+		Joins("JOIN category_package p ON p.id = o.package_id").
+		Joins("JOIN venue_category_package c ON c.id = p.category_id")
+	if len(venueIDs) > 0 {
+		qb = qb.Where("c.venue_id IN (?)", venueIDs)
+	}
+	return qb
+}
+
+func (r *repository) GetOwnerOrders(ctx context.Context, param *entity.OwnerOrderQuery) ([]*entity.OwnerOrder, *entity.Pagination, error) {
+	if param.Page <= 0 {
+		param.Page = 1
+	}
+	qb := r.venueOrders(ctx, param.VenueIDs).
+		Joins("JOIN venue v ON v.id = c.venue_id").
+		Joins("LEFT JOIN auth a ON a.id = o.user_id")
+	if param.PackageID > 0 {
+		qb = qb.Where("o.package_id = ?", param.PackageID)
+	}
+	if param.Status != "" {
+		qb = qb.Where("o.status = ?", param.Status)
+	}
+	if !param.StartDate.IsZero() {
+		qb = qb.Where("o.date >= ?", param.StartDate)
+	}
+	if !param.EndDate.IsZero() {
+		qb = qb.Where("o.date <= ?", param.EndDate)
+	}
+
+	selectOrders := func(qb *gorm.DB) ([]*entity.OwnerOrder, error) {
+		var out []*entity.OwnerOrder
+		err := qb.Select("o.id, o.invoice_number, c.venue_id, v.name AS venue_name, o.package_id, p.name AS package_name, " +
+			"a.fullname AS customer_name, a.email AS customer_email, o.date, o.status, o.payment_status, o.total_price, o.created_at").
+			Order("o.date asc, o.id asc").
+			Scan(&out).Error
+		if err != nil {
+			return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetOwnerOrders] err: %v", err))
+		}
+		return out, nil
+	}
+	if param.IsWithoutPagination {
+		out, err := selectOrders(qb)
+		return out, nil, err
+	}
+
+	var totalRecords int64
+	err := qb.Session(&gorm.Session{}).Count(&totalRecords).Error
+	if err != nil {
+		return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetOwnerOrders] err: %v", err))
+	}
+	out, err := selectOrders(qb.Limit(param.Limit).Offset((param.Page - 1) * param.Limit))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totalPage := math.Ceil(float64(totalRecords) / float64(param.Limit))
+	return out, &entity.Pagination{
+		Page:         param.Page,
+		TotalPage:    int(totalPage),
+		CurrentItems: len(out),
+		TotalItems:   int(totalRecords),
+	}, nil
+}
+
+func (r *repository) GetMonthlyBookingStats(ctx context.Context, param *entity.VenueStatsQuery) ([]*entity.MonthlyBookingStat, error) {
+	var out []*entity.MonthlyBookingStat
+	err := r.venueOrders(ctx, param.VenueIDs).
+		Select("DATE_FORMAT(o.created_at, '%Y-%m') AS month, COUNT(*) AS bookings, "+
+			"SUM(o.status = ?) AS cancelled, "+
+			"COALESCE(SUM(CASE WHEN o.status <> ? THEN o.total_price ELSE 0 END), 0) AS revenue",
+			entity.OrderStatusCancelled, entity.OrderStatusCancelled).
+		Where("o.created_at >= ?", param.StartDate).
+		Where("o.created_at < ?", param.EndDate).
+		Group("month").
+		Order("month asc").
+		Scan(&out).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetMonthlyBookingStats] err: %v", err))
+	}
+	return out, nil
+}
+
+func (r *repository) GetTopPackageStats(ctx context.Context, param *entity.VenueStatsQuery, limit int) ([]*entity.PackageBookingStat, error) {
+	var out []*entity.PackageBookingStat
+	err := r.venueOrders(ctx, param.VenueIDs).
+		Select("p.id AS package_id, p.name AS package_name, COUNT(*) AS bookings, COALESCE(SUM(o.total_price), 0) AS revenue").
+		Where("o.status <> ?", entity.OrderStatusCancelled).
+		Where("o.created_at >= ?", param.StartDate).
+		Where("o.created_at < ?", param.EndDate).
+		Group("p.id, p.name").
+		Order("bookings desc, revenue desc").
+		Limit(limit).
+		Scan(&out).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetTopPackageStats] err: %v", err))
+	}
+	return out, nil
+}
+
+func (r *repository) CountVenueViews(ctx context.Context, param *entity.VenueStatsQuery) (int, error) {
+	var total int64
+	qb := r.db.WithContext(ctx).Table("venue_view_stat").
+		Select("COALESCE(SUM(views), 0)").
+		Where("date >= ?", param.StartDate).
+		Where("date < ?", param.EndDate)
+	if len(param.VenueIDs) > 0 {
+		qb = qb.Where("venue_id IN (?)", param.VenueIDs)
+	}
+	err := qb.Scan(&total).Error
+	if err != nil {
+		return 0, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CountVenueViews] err: %v", err))
+	}
+	return int(total), nil
+}
@@ -1,46 +1,58 @@
 package venue
 
-import "github.com/faruqfadhil/venue-api/core/entity"
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+)
 
 type Venue struct {
-	ID           int
-	Name         string
-	MinPrice     float64
-	MaxPrice     float64
-	Capacity     int
-	Star         float64
-	ReviewCount  int
-	ThumbnailURL string
-	Description  string
-	Website      string
-	Phone        string
-	Email        string
-	Instagram    string
-	Address      string
-	Logo         string
-	IsFavourite  bool
-	CityID       int
+	ID            int
+	Name          string
+	MinPrice      float64
+	MaxPrice      float64
+	Capacity      int
+	Star          float64
+	ReviewCount   int
+	ThumbnailURL  string
+	Description   string
+	Website       string
+	Phone         string
+	Email         string
+	Instagram     string
+	Address       string
+	Logo          string
+	IsFavourite   bool
+	CityID        int
+	Timezone      string
+	CalendarToken string
+	UpdatedAt     time.Time
 }
 
 func (v *Venue) ToEntity() *entity.Venue {
 	return &entity.Venue{
-		ID:           v.ID,
-		Name:         v.Name,
-		MinPrice:     v.MinPrice,
-		MaxPrice:     v.MaxPrice,
-		Capacity:     v.Capacity,
-		Star:         v.Star,
-		ReviewCount:  v.ReviewCount,
-		ThumbnailURL: v.ThumbnailURL,
-		Description:  v.Description,
-		Website:      v.Website,
-		Phone:        v.Phone,
-		Email:        v.Email,
-		Instagram:    v.Instagram,
-		Address:      v.Address,
-		Logo:         v.Logo,
-		IsFavourite:  v.IsFavourite,
-		CityID:       v.CityID,
+		ID:            v.ID,
+		Name:          v.Name,
+		MinPrice:      v.MinPrice,
+		MaxPrice:      v.MaxPrice,
+		Capacity:      v.Capacity,
+		Star:          v.Star,
+		ReviewCount:   v.ReviewCount,
+		ThumbnailURL:  v.ThumbnailURL,
+		Description:   v.Description,
+		Website:       v.Website,
+		Phone:         v.Phone,
+		Email:         v.Email,
+		Instagram:     v.Instagram,
+		Address:       v.Address,
+		Logo:          v.Logo,
+		IsFavourite:   v.IsFavourite,
+		CityID:        v.CityID,
+		Timezone:      v.Timezone,
+		CalendarToken: v.CalendarToken,
+		UpdatedAt:     v.UpdatedAt,
 	}
 }
 
@@ -54,6 +66,7 @@ type VenuePackageCategory struct {
 	ID          int
 	VenueID     int
 	Description string
+	UpdatedAt   time.Time
 }
 
 func (v *VenuePackageCategory) ToEntity() *entity.VenuePackageCategory {
@@ -61,6 +74,7 @@ func (v *VenuePackageCategory) ToEntity() *entity.VenuePackageCategory {
 		ID:          v.ID,
 		VenueID:     v.VenueID,
 		Description: v.Description,
+		UpdatedAt:   v.UpdatedAt,
 	}
 }
 
@@ -72,6 +86,7 @@ type VenuePackage struct {
 	Price        float64
 	Capacity     int
 	Description  string
+	UpdatedAt    time.Time
 }
 
 func (v *VenuePackage) ToEntity() *entity.VenuePackage {
@@ -83,5 +98,280 @@ func (v *VenuePackage) ToEntity() *entity.VenuePackage {
 		Price:        v.Price,
 		Capacity:     v.Capacity,
 		Description:  v.Description,
+		UpdatedAt:    v.UpdatedAt,
+	}
+}
+
+type PackageAddon struct {
+	ID          int
+	PackageID   int
+	Name        string
+	Description string
+	Unit        string
+	UnitPrice   float64
+	MinQuantity int
+	MaxQuantity int
+	UpdatedAt   time.Time
+}
+
+func (p *PackageAddon) ToEntity() *entity.PackageAddon {
+	return &entity.PackageAddon{
+		ID:          p.ID,
+		PackageID:   p.PackageID,
+		Name:        p.Name,
+		Description: p.Description,
+		Unit:        p.Unit,
+		UnitPrice:   p.UnitPrice,
+		MinQuantity: p.MinQuantity,
+		MaxQuantity: p.MaxQuantity,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+type VenueOwner struct {
+	ID      int
+	VenueID int
+	UserID  int
+}
+
+type PackagePriceRule struct {
+	ID              int
+	PackageID       int
+	Name            string
+	RuleType        string
+	DaysOfWeek      string
+	StartDate       *time.Time
+	EndDate         *time.Time
+	MinLeadDays     int
+	MaxLeadDays     int
+	AdjustmentType  string
+	AdjustmentValue float64
+	Priority        int
+}
+
+func (p *PackagePriceRule) ToEntity() *entity.PriceRule {
+	out := &entity.PriceRule{
+		ID:              p.ID,
+		PackageID:       p.PackageID,
+		Name:            p.Name,
+		RuleType:        p.RuleType,
+		MinLeadDays:     p.MinLeadDays,
+		MaxLeadDays:     p.MaxLeadDays,
+		AdjustmentType:  p.AdjustmentType,
+		AdjustmentValue: p.AdjustmentValue,
+		Priority:        p.Priority,
+	}
+	if p.StartDate != nil {
+		out.StartDate = *p.StartDate
+	}
+	if p.EndDate != nil {
+		out.EndDate = *p.EndDate
+	}
+	for _, d := range strings.Split(p.DaysOfWeek, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(d))
+		if err != nil || day < 0 || day > 6 {
+			continue
+		}
+		out.DaysOfWeek = append(out.DaysOfWeek, time.Weekday(day))
+	}
+	return out
+}
+
+func newPackagePriceRule(e *entity.PriceRule) *PackagePriceRule {
+	out := &PackagePriceRule{
+		ID:              e.ID,
+		PackageID:       e.PackageID,
+		Name:            e.Name,
+		RuleType:        e.RuleType,
+		MinLeadDays:     e.MinLeadDays,
+		MaxLeadDays:     e.MaxLeadDays,
+		AdjustmentType:  e.AdjustmentType,
+		AdjustmentValue: e.AdjustmentValue,
+		Priority:        e.Priority,
+	}
+	if !e.StartDate.IsZero() {
+		out.StartDate = &e.StartDate
+	}
+	if !e.EndDate.IsZero() {
+		out.EndDate = &e.EndDate
+	}
+	days := []string{}
+	for _, d := range e.DaysOfWeek {
+		days = append(days, strconv.Itoa(int(d)))
+	}
+	out.DaysOfWeek = strings.Join(days, ",")
+	return out
+}
+
+type Promo struct {
+	ID                int
+	Code              string
+	Description       string
+	DiscountType      string
+	DiscountValue     float64
+	MaxDiscount       float64
+	MinSpend          float64
+	UsageLimit        int
+	UsageLimitPerUser int
+	UsedCount         int
+	StartsAt          *time.Time
+	EndsAt            *time.Time
+	VenueID           int
+	CityID            int
+	PackageID         int
+	IsActive          bool
+}
+
+func (p *Promo) ToEntity() *entity.Promo {
+	out := &entity.Promo{
+		ID:                p.ID,
+		Code:              p.Code,
+		Description:       p.Description,
+		DiscountType:      p.DiscountType,
+		DiscountValue:     p.DiscountValue,
+		MaxDiscount:       p.MaxDiscount,
+		MinSpend:          p.MinSpend,
+		UsageLimit:        p.UsageLimit,
+		UsageLimitPerUser: p.UsageLimitPerUser,
+		UsedCount:         p.UsedCount,
+		VenueID:           p.VenueID,
+		CityID:            p.CityID,
+		PackageID:         p.PackageID,
+		IsActive:          p.IsActive,
+	}
+	if p.StartsAt != nil {
+		out.StartsAt = *p.StartsAt
+	}
+	if p.EndsAt != nil {
+		out.EndsAt = *p.EndsAt
+	}
+	return out
+}
+
+func newPromo(e *entity.Promo) *Promo {
+	out := &Promo{
+		ID:                e.ID,
+		Code:              e.Code,
+		Description:       e.Description,
+		DiscountType:      e.DiscountType,
+		DiscountValue:     e.DiscountValue,
+		MaxDiscount:       e.MaxDiscount,
+		MinSpend:          e.MinSpend,
+		UsageLimit:        e.UsageLimit,
+		UsageLimitPerUser: e.UsageLimitPerUser,
+		UsedCount:         e.UsedCount,
+		VenueID:           e.VenueID,
+		CityID:            e.CityID,
+		PackageID:         e.PackageID,
+		IsActive:          e.IsActive,
+	}
+	if !e.StartsAt.IsZero() {
+		out.StartsAt = &e.StartsAt
+	}
+	if !e.EndsAt.IsZero() {
+		out.EndsAt = &e.EndsAt
+	}
+	return out
+}
+
+type PromoUsage struct {
+	ID       int
+	PromoID  int
+	UserID   int
+	OrderID  int
+	Discount float64
+}
+
+// The imported* DTOs carry the external key and audit columns written by the
+// bulk import.
+type importedVenue struct {
+	ID           int
+	ExternalID   string
+	Name         string
+	CityID       int
+	Capacity     int
+	ThumbnailURL string
+	Description  string
+	Website      string
+	Phone        string
+	Email        string
+	Instagram    string
+	Address      string
+	Logo         string
+	Timezone     string
+	CreatedBy    string
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+func newImportedVenue(v *entity.ImportVenue, by string) *importedVenue {
+	return &importedVenue{
+		ExternalID:   v.ExternalID,
+		Name:         v.Name,
+		CityID:       v.CityID,
+		Capacity:     v.Capacity,
+		ThumbnailURL: v.ThumbnailURL,
+		Description:  v.Description,
+		Website:      v.Website,
+		Phone:        v.Phone,
+		Email:        v.Email,
+		Instagram:    v.Instagram,
+		Address:      v.Address,
+		Logo:         v.Logo,
+		Timezone:     v.Timezone,
+		CreatedBy:    by,
+		UpdatedBy:    by,
+	}
+}
+
+type importedVenueGallery struct {
+	ID         int
+	ExternalID string
+	VenueID    int
+	FileURL    string
+	CreatedBy  string
+	UpdatedBy  string
+	UpdatedAt  time.Time
+}
+
+type importedVenueCategory struct {
+	ID          int
+	ExternalID  string
+	VenueID     int
+	Description string
+	CreatedBy   string
+	UpdatedBy   string
+	UpdatedAt   time.Time
+}
+
+type importedVenuePackage struct {
+	ID           int
+	ExternalID   string
+	CategoryID   int
+	Name         string
+	ThumbnailURL string
+	Description  string
+	Price        float64
+	Capacity     int
+	CreatedBy    string
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+type ContentTranslation struct {
+	ContentType string
+	ContentID   int
+	Locale      string
+	Field       string
+	Value       string
+}
+
+func (t *ContentTranslation) ToEntity() *entity.Translation {
+	return &entity.Translation{
+		ContentType: t.ContentType,
+		ContentID:   t.ContentID,
+		Locale:      t.Locale,
+		Field:       t.Field,
+		Value:       t.Value,
 	}
 }
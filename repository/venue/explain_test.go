@@ -0,0 +1,271 @@
+package venue
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// queryRecorder keeps every statement run through it, the values inlined.
+type queryRecorder struct {
+	logger.Interface
+	queries []string
+}
+
+func (r *queryRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *queryRecorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	query, _ := fc()
+	r.queries = append(r.queries, query)
+}
+
+// dataset is a generated catalog big enough for the optimizer to prefer the
+// indexes, with the rows the hot queries are run against.
+type dataset struct {
+	CityID     int
+	VenueID    int
+	CategoryID int
+	PackageID  int
+	OrderDate  time.Time
+	Email      string
+}
+
+const (
+	datasetCities              = 20
+	datasetVenues              = 400
+	datasetGalleriesPerVenue   = 3
+	datasetCategoriesPerVenue  = 2
+	datasetPackagesPerCategory = 2
+	datasetOrdersPerPackage    = 8
+	datasetUsers               = 200
+	datasetBatch               = 500
+)
+
+func seedDataset(t *testing.T, db *gorm.DB) *dataset {
+	t.Helper()
+	tag := fmt.Sprintf("explain-%d", time.Now().UnixNano())
+	insert := func(table, columns string, n int, row func(i int) []interface{}) {
+		t.Helper()
+		placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", strings.Count(columns, ",")+1), ", ") + ")"
+		for start := 0; start < n; start += datasetBatch {
+			end := start + datasetBatch
+			if end > n {
+				end = n
+			}
+			values := []string{}
+			args := []interface{}{}
+			for i := start; i < end; i++ {
+				values = append(values, placeholders)
+				args = append(args, row(i)...)
+			}
+			query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, columns, strings.Join(values, ", "))
+			if err := db.Exec(query, args...).Error; err != nil {
+				t.Fatalf("seed %s: %v", table, err)
+			}
+		}
+	}
+	ids := func(query string, args ...interface{}) []int {
+		t.Helper()
+		out := []int{}
+		if err := db.Raw(query, args...).Scan(&out).Error; err != nil {
+			t.Fatalf("read ids %q: %v", query, err)
+		}
+		return out
+	}
+
+	insert("city", "name", datasetCities, func(i int) []interface{} {
+		return []interface{}{fmt.Sprintf("%s-city-%d", tag, i)}
+	})
+	cityIDs := ids("SELECT id FROM city WHERE name LIKE ? ORDER BY id", tag+"-%")
+
+	insert("venue", "external_id, name, thumbnail_url, city_id, description, website, address, logo, min_price, star, capacity", datasetVenues, func(i int) []interface{} {
+		return []interface{}{fmt.Sprintf("%s-v-%d", tag, i), fmt.Sprintf("Venue %d", i), "", cityIDs[i%len(cityIDs)], "", "", "", "", 1000 + i%97*10, float64(i%50) / 10, 50 + i%31*10}
+	})
+	venueIDs := ids("SELECT id FROM venue WHERE external_id LIKE ? ORDER BY id", tag+"-%")
+
+	insert("venue_gallery", "venue_id, file_url", len(venueIDs)*datasetGalleriesPerVenue, func(i int) []interface{} {
+		return []interface{}{venueIDs[i/datasetGalleriesPerVenue], fmt.Sprintf("https://example.com/%d.jpg", i)}
+	})
+
+	insert("venue_category_package", "external_id, venue_id, description", len(venueIDs)*datasetCategoriesPerVenue, func(i int) []interface{} {
+		return []interface{}{fmt.Sprintf("%s-c-%d", tag, i), venueIDs[i/datasetCategoriesPerVenue], fmt.Sprintf("Category %d", i)}
+	})
+	categoryIDs := ids("SELECT id FROM venue_category_package WHERE external_id LIKE ? ORDER BY id", tag+"-%")
+
+	insert("category_package", "external_id, category_id, name, thumbnail_url, description, price, capacity", len(categoryIDs)*datasetPackagesPerCategory, func(i int) []interface{} {
+		return []interface{}{fmt.Sprintf("%s-p-%d", tag, i), categoryIDs[i/datasetPackagesPerCategory], fmt.Sprintf("Package %d", i), "", "", 1000 + i, 100}
+	})
+	packageIDs := ids("SELECT id FROM category_package WHERE external_id LIKE ? ORDER BY id", tag+"-%")
+
+	insert("auth", "email, fullname, password, role", datasetUsers, func(i int) []interface{} {
+		return []interface{}{fmt.Sprintf("%s-%d@example.com", tag, i), fmt.Sprintf("User %d", i), "", entity.RoleCustomer}
+	})
+	userIDs := ids("SELECT id FROM auth WHERE email LIKE ? ORDER BY id", tag+"-%")
+
+	firstDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	insert("`order`", "package_id, user_id, date, status, package_price, total_price", len(packageIDs)*datasetOrdersPerPackage, func(i int) []interface{} {
+		status := entity.OrderStatusConfirmed
+		if i%10 == 5 {
+			status = entity.OrderStatusCancelled
+		}
+		date := firstDate.AddDate(0, 0, (i%datasetOrdersPerPackage)*7+i/datasetOrdersPerPackage%7)
+		return []interface{}{packageIDs[i/datasetOrdersPerPackage], userIDs[i%len(userIDs)], date, status, 1000, 1110}
+	})
+
+	for _, table := range []string{"city", "venue", "venue_gallery", "venue_category_package", "category_package", "auth", "`order`"} {
+		if err := db.Exec("ANALYZE TABLE " + table).Error; err != nil {
+			t.Fatalf("analyze %s: %v", table, err)
+		}
+	}
+
+	// The first order of the first package is confirmed, on firstDate.
+	return &dataset{
+		CityID:     cityIDs[0],
+		VenueID:    venueIDs[0],
+		CategoryID: categoryIDs[0],
+		PackageID:  packageIDs[0],
+		OrderDate:  firstDate,
+		Email:      fmt.Sprintf("%s-%d@example.com", tag, 7),
+	}
+}
+
+// TestHotQueriesUseIndexes runs the hot reads of the repository on a
+// generated dataset and checks with EXPLAIN that none scans a whole table.
+func TestHotQueriesUseIndexes(t *testing.T) {
+	db := openTestDB(t)
+	ds := seedDataset(t, db)
+	ctx := context.Background()
+
+	type hotQuery struct {
+		name string
+		run  func(repo *repository) error
+	}
+	tests := []hotQuery{
+		{"GetVenues by city", func(repo *repository) error {
+			_, _, err := repo.GetVenues(ctx, entity.GetVenuesParam{CityID: ds.CityID, Limit: 10})
+			return err
+		}},
+		{"GetGalleriesByVenueIDs", func(repo *repository) error {
+			_, err := repo.GetGalleriesByVenueIDs(ctx, []int{ds.VenueID})
+			return err
+		}},
+		{"GetVenueCategoryPackageByQuery by venue", func(repo *repository) error {
+			_, err := repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{VenueID: ds.VenueID})
+			return err
+		}},
+		{"GetVenueCategoryPackageByQuery by venues", func(repo *repository) error {
+			_, err := repo.GetVenueCategoryPackageByQuery(ctx, &entity.GetVenueCategoryByQuery{VenueIDs: []int{ds.VenueID}})
+			return err
+		}},
+		{"GetVenuePackageByQuery by categories", func(repo *repository) error {
+			_, err := repo.GetVenuePackageByQuery(ctx, &entity.GetVenuePackageQuery{CategoryIDs: []int{ds.CategoryID}})
+			return err
+		}},
+		{"GetOrderByPackageIDAndDate", func(repo *repository) error {
+			_, err := repo.GetOrderByPackageIDAndDate(ctx, ds.PackageID, ds.OrderDate)
+			return err
+		}},
+		{"GetOwnerOrders", func(repo *repository) error {
+			_, _, err := repo.GetOwnerOrders(ctx, &entity.OwnerOrderQuery{
+				VenueIDs:  []int{ds.VenueID},
+				Status:    entity.OrderStatusConfirmed,
+				StartDate: ds.OrderDate,
+				EndDate:   ds.OrderDate.AddDate(0, 1, -1),
+				Limit:     10,
+			})
+			return err
+		}},
+		{"GetOwnerOrders without pagination", func(repo *repository) error {
+			_, _, err := repo.GetOwnerOrders(ctx, &entity.OwnerOrderQuery{
+				VenueIDs:            []int{ds.VenueID},
+				Status:              entity.OrderStatusConfirmed,
+				StartDate:           ds.OrderDate,
+				EndDate:             ds.OrderDate.AddDate(0, 1, -1),
+				IsWithoutPagination: true,
+			})
+			return err
+		}},
+		{"FindUserByEmail", func(repo *repository) error {
+			_, err := repo.FindUserByEmail(ctx, ds.Email)
+			return err
+		}},
+		{"FindUserByEmail in another case", func(repo *repository) error {
+			_, err := repo.FindUserByEmail(ctx, strings.ToUpper(ds.Email))
+			return err
+		}},
+	}
+	// Every sort of the venue list, on its first page and after a cursor.
+	for _, column := range []string{entity.VenueSortPrice, entity.VenueSortStar, entity.VenueSortCapacity} {
+		for _, sort := range []string{column, "-" + column} {
+			sort := sort
+			tests = append(tests, hotQuery{"GetVenues sorted by " + sort, func(repo *repository) error {
+				_, _, err := repo.GetVenues(ctx, entity.GetVenuesParam{Sort: sort, Page: 1, Limit: 10, SkipCount: true})
+				return err
+			}}, hotQuery{"GetVenues sorted by " + sort + " after a cursor", func(repo *repository) error {
+				cursor := encodeVenueCursor(venueCursor{Sort: sort, Key: 2, ID: ds.VenueID})
+				_, _, err := repo.GetVenues(ctx, entity.GetVenuesParam{Sort: sort, Cursor: cursor, Limit: 10, SkipCount: true})
+				return err
+			}})
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &queryRecorder{Interface: logger.Default.LogMode(logger.Silent)}
+			repo := newTestRepository(db.Session(&gorm.Session{Logger: rec}))
+			if err := tt.run(repo); err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			if len(rec.queries) < 1 {
+				t.Fatal("no query recorded")
+			}
+			for _, query := range rec.queries {
+				assertIndexed(t, db, query)
+			}
+		})
+	}
+}
+
+// assertIndexed fails when a table of the plan of query is read without a
+// key or with a full scan.
+func assertIndexed(t *testing.T, db *gorm.DB, query string) {
+	t.Helper()
+	rows, err := db.Raw("EXPLAIN " + query).Rows()
+	if err != nil {
+		t.Fatalf("explain %s: %v", query, err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatalf("explain %s: %v", query, err)
+	}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			t.Fatalf("explain %s: %v", query, err)
+		}
+		plan := map[string]sql.NullString{}
+		for i, c := range columns {
+			plan[c] = values[i]
+		}
+		if !plan["key"].Valid || plan["type"].String == "ALL" {
+			t.Errorf("%s\n  reads %s with type %s and key %s (possible keys %s, %s)",
+				query, plan["table"].String, plan["type"].String, plan["key"].String, plan["possible_keys"].String, plan["Extra"].String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("explain %s: %v", query, err)
+	}
+}
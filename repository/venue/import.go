@@ -0,0 +1,146 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errImportDryRun rolls back the import transaction once every statement has
+// run, so a dry run reports exactly what a real import would do.
+var errImportDryRun = errors.New("dry run")
+
+func (r *repository) ImportVenues(ctx context.Context, data *entity.VenueImport, param *entity.ImportParam) (*entity.ImportResult, error) {
+	out := &entity.ImportResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		venueIDs := []int{}
+		for _, v := range data.Venues {
+			venue := newImportedVenue(v, param.ImportedBy)
+			created, err := upsertByExternalID(tx, "venue", venue.ExternalID, venue, &venue.ID,
+				"name", "city_id", "capacity", "thumbnail_url", "description", "website", "phone",
+				"email", "instagram", "address", "logo", "timezone")
+			if err != nil {
+				return err
+			}
+			countImport(&out.Venues, created)
+			venueIDs = append(venueIDs, venue.ID)
+
+			for _, g := range v.Galleries {
+				gallery := &importedVenueGallery{
+					ExternalID: g.ExternalID,
+					VenueID:    venue.ID,
+					FileURL:    g.FileURL,
+					CreatedBy:  param.ImportedBy,
+					UpdatedBy:  param.ImportedBy,
+				}
+				created, err := upsertByExternalID(tx, "venue_gallery", gallery.ExternalID, gallery, &gallery.ID,
+					"venue_id", "file_url")
+				if err != nil {
+					return err
+				}
+				countImport(&out.Galleries, created)
+			}
+
+			for _, c := range v.Categories {
+				category := &importedVenueCategory{
+					ExternalID:  c.ExternalID,
+					VenueID:     venue.ID,
+					Description: c.Description,
+					CreatedBy:   param.ImportedBy,
+					UpdatedBy:   param.ImportedBy,
+				}
+				created, err := upsertByExternalID(tx, "venue_category_package", category.ExternalID, category, &category.ID,
+					"venue_id", "description")
+				if err != nil {
+					return err
+				}
+				countImport(&out.Categories, created)
+
+				for _, p := range c.Packages {
+					pkg := &importedVenuePackage{
+						ExternalID:   p.ExternalID,
+						CategoryID:   category.ID,
+						Name:         p.Name,
+						ThumbnailURL: p.ThumbnailURL,
+						Description:  p.Description,
+						Price:        p.Price,
+						Capacity:     p.Capacity,
+						CreatedBy:    param.ImportedBy,
+						UpdatedBy:    param.ImportedBy,
+					}
+					created, err := upsertByExternalID(tx, "category_package", pkg.ExternalID, pkg, &pkg.ID,
+						"category_id", "name", "thumbnail_url", "description", "price", "capacity")
+					if err != nil {
+						return err
+					}
+					countImport(&out.Packages, created)
+				}
+			}
+		}
+
+		if len(venueIDs) > 0 {
+			err := tx.Exec("UPDATE venue v SET "+
+				"min_price = COALESCE((SELECT MIN(p.price) FROM category_package p JOIN venue_category_package c ON c.id = p.category_id WHERE c.venue_id = v.id), 0), "+
+				"max_price = COALESCE((SELECT MAX(p.price) FROM category_package p JOIN venue_category_package c ON c.id = p.category_id WHERE c.venue_id = v.id), 0) "+
+				"WHERE v.id IN ?", venueIDs).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if param.DryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[ImportVenues] err: %v", err))
+	}
+	return out, nil
+}
+
+// upsertByExternalID updates the row of table holding externalID or inserts
+// dto when there is none, id is set to the row id in both cases. Only columns
+// and the update audit columns are overwritten on update.
+func upsertByExternalID(tx *gorm.DB, table, externalID string, dto interface{}, id *int, columns ...string) (bool, error) {
+	var existing struct {
+		ID int
+	}
+	err := tx.Table(table).
+		Select("id").
+		Where("external_id = ?", externalID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Table(table).Create(dto).Error; err != nil {
+			return false, fmt.Errorf("insert %s %q: %v", table, externalID, err)
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	*id = existing.ID
+	err = tx.Table(table).
+		Where("id = ?", existing.ID).
+		Select(append(columns, "updated_by", "updated_at")).
+		Updates(dto).Error
+	if err != nil {
+		return false, fmt.Errorf("update %s %q: %v", table, externalID, err)
+	}
+	return false, nil
+}
+
+func countImport(count *entity.ImportCount, created bool) {
+	if created {
+		count.Created++
+		return
+	}
+	count.Updated++
+}
@@ -0,0 +1,105 @@
+package venue
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	schema "github.com/faruqfadhil/venue-api/db"
+	"github.com/faruqfadhil/venue-api/pkg/migrate"
+	"github.com/go-sql-driver/mysql"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// The tests talking to MySQL run when MYSQL_TEST_DSN points to a throwaway
+// database, e.g. root:secret@tcp(localhost:3306)/venue_test. The migrations
+// are applied and the rows they insert are left behind.
+const testDSNEnv = "MYSQL_TEST_DSN"
+
+// openTestDB connects with the session settings of the app, see
+// config.MySQL.DSN, and migrates the database.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("parse %s: %v", testDSNEnv, err)
+	}
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["charset"] = "utf8mb4"
+	cfg.Params["time_zone"] = "'+00:00'"
+
+	sqlDB, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	migrations, err := migrate.Load(schema.Migrations, "migrations")
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrate.New(sqlDB, "schema_migrations", migrations).Up(context.Background(), 0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	db, err := gorm.Open(gormmysql.New(gormmysql.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("gorm: %v", err)
+	}
+	return db
+}
+
+func newTestRepository(db *gorm.DB) *repository {
+	return &repository{
+		db: db,
+	}
+}
+
+// fixture is a venue with one category and package, and a customer.
+type fixture struct {
+	CityID     int
+	VenueID    int
+	CategoryID int
+	PackageID  int
+	UserID     int
+}
+
+func seedFixture(t *testing.T, db *gorm.DB, timezone string) *fixture {
+	t.Helper()
+	exec := func(query string, args ...interface{}) int {
+		t.Helper()
+		res, err := db.ConnPool.ExecContext(context.Background(), query, args...)
+		if err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			t.Fatalf("seed %q: %v", query, err)
+		}
+		return int(id)
+	}
+	f := &fixture{}
+	f.CityID = exec("INSERT INTO city (name) VALUES (?)", "Test City")
+	f.VenueID = exec("INSERT INTO venue (name, thumbnail_url, city_id, description, website, address, logo, timezone) VALUES (?, '', ?, '', '', '', '', ?)",
+		"Test Venue", f.CityID, timezone)
+	f.CategoryID = exec("INSERT INTO venue_category_package (venue_id, description) VALUES (?, ?)", f.VenueID, "Test Category")
+	f.PackageID = exec("INSERT INTO category_package (category_id, name, thumbnail_url, description, price, capacity) VALUES (?, ?, '', '', 1000, 100)",
+		f.CategoryID, "Test Package")
+	f.UserID = exec("INSERT INTO auth (email, fullname, password, role) VALUES (?, 'Test User', '', ?)",
+		fmt.Sprintf("test-%d@example.com", time.Now().UnixNano()), entity.RoleCustomer)
+	return f
+}
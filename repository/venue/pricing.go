@@ -0,0 +1,58 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+)
+
+func (r *repository) GetPriceRuleByID(ctx context.Context, ID int) (*entity.PriceRule, error) {
+	var out PackagePriceRule
+	err := r.db.WithContext(ctx).Table("package_price_rule").
+		Where("id = ?", ID).
+		First(&out).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("[GetPriceRuleByID] err: %v", err))
+		}
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPriceRuleByID] err: %v", err))
+	}
+	return out.ToEntity(), nil
+}
+
+func (r *repository) CreatePriceRule(ctx context.Context, rule *entity.PriceRule) error {
+	dto := newPackagePriceRule(rule)
+	err := r.db.WithContext(ctx).Table("package_price_rule").Create(dto).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CreatePriceRule] err: %v", err))
+	}
+	rule.ID = dto.ID
+	return nil
+}
+
+func (r *repository) UpdatePriceRule(ctx context.Context, rule *entity.PriceRule) error {
+	dto := newPackagePriceRule(rule)
+	err := r.db.WithContext(ctx).Table("package_price_rule").
+		Where("id = ?", rule.ID).
+		Select("name", "rule_type", "days_of_week", "start_date", "end_date", "min_lead_days", "max_lead_days",
+			"adjustment_type", "adjustment_value", "priority").
+		Updates(dto).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[UpdatePriceRule] err: %v", err))
+	}
+	return nil
+}
+
+func (r *repository) DeletePriceRule(ctx context.Context, ID int) error {
+	err := r.db.WithContext(ctx).Table("package_price_rule").
+		Where("id = ?", ID).
+		Delete(&PackagePriceRule{}).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[DeletePriceRule] err: %v", err))
+	}
+	return nil
+}
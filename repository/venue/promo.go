@@ -0,0 +1,149 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) CreatePromo(ctx context.Context, promo *entity.Promo) error {
+	dto := newPromo(promo)
+	err := r.db.WithContext(ctx).Table("promo").Create(dto).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CreatePromo] err: %v", err))
+	}
+	promo.ID = dto.ID
+	return nil
+}
+
+func (r *repository) UpdatePromo(ctx context.Context, promo *entity.Promo) error {
+	dto := newPromo(promo)
+	err := r.db.WithContext(ctx).Table("promo").
+		Where("id = ?", promo.ID).
+		Select("code", "description", "discount_type", "discount_value", "max_discount", "min_spend",
+			"usage_limit", "usage_limit_per_user", "starts_at", "ends_at", "venue_id", "city_id", "package_id", "is_active").
+		Updates(dto).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[UpdatePromo] err: %v", err))
+	}
+	return nil
+}
+
+func (r *repository) GetPromoByID(ctx context.Context, ID int) (*entity.Promo, error) {
+	var out Promo
+	err := r.db.WithContext(ctx).Table("promo").
+		Where("id = ?", ID).
+		First(&out).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("[GetPromoByID] err: %v", err))
+		}
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPromoByID] err: %v", err))
+	}
+	return out.ToEntity(), nil
+}
+
+func (r *repository) GetPromoByCode(ctx context.Context, code string) (*entity.Promo, error) {
+	var out Promo
+	err := r.db.WithContext(ctx).Table("promo").
+		Where("code = ?", strings.ToUpper(code)).
+		First(&out).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("[GetPromoByCode] err: %v", err))
+		}
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPromoByCode] err: %v", err))
+	}
+	return out.ToEntity(), nil
+}
+
+func (r *repository) GetPromos(ctx context.Context, param entity.GetPromosParam) ([]*entity.Promo, *entity.Pagination, error) {
+	var result []*Promo
+	if param.Page <= 0 {
+		param.Page = 1
+	}
+	qb := r.db.WithContext(ctx).Table("promo")
+	if param.Code != "" {
+		qb = qb.Where("code LIKE ?", "%"+strings.ToUpper(param.Code)+"%")
+	}
+	if param.IsActive {
+		qb = qb.Where("is_active = ?", param.IsActive)
+	}
+
+	var totalRecords int64
+	err := qb.Count(&totalRecords).Error
+	if err != nil {
+		return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPromos] err: %v", err))
+	}
+	offset := (param.Page - 1) * param.Limit
+	err = qb.Order("id desc").Limit(param.Limit).Offset(offset).Find(&result).Error
+	if err != nil {
+		return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPromos] err: %v", err))
+	}
+
+	out := []*entity.Promo{}
+	for _, r := range result {
+		out = append(out, r.ToEntity())
+	}
+	totalPage := math.Ceil(float64(totalRecords) / float64(param.Limit))
+	return out, &entity.Pagination{
+		Page:         param.Page,
+		TotalPage:    int(totalPage),
+		CurrentItems: len(result),
+		TotalItems:   int(totalRecords),
+	}, nil
+}
+
+func (r *repository) CountPromoUsageByUser(ctx context.Context, promoID, userID int) (int, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Table("promo_usage").
+		Where("promo_id = ?", promoID).
+		Where("user_id = ?", userID).
+		Count(&total).Error
+	if err != nil {
+		return 0, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CountPromoUsageByUser] err: %v", err))
+	}
+	return int(total), nil
+}
+
+// claimPromo locks the promo row for the rest of the transaction, re-checks
+// the usage limits against the locked state and consumes one usage.
+func claimPromo(tx *gorm.DB, promoID, userID int) error {
+	var promo Promo
+	err := tx.Table("promo").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", promoID).
+		First(&promo).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoNotFound, fmt.Errorf("[claimPromo] err: %v", err))
+		}
+		return err
+	}
+	if promo.UsageLimit > 0 && promo.UsedCount >= promo.UsageLimit {
+		return errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoQuotaExhausted, fmt.Errorf("[claimPromo] err: promo %d usage limit reached", promoID))
+	}
+	if promo.UsageLimitPerUser > 0 {
+		var used int64
+		err := tx.Table("promo_usage").
+			Where("promo_id = ?", promoID).
+			Where("user_id = ?", userID).
+			Count(&used).Error
+		if err != nil {
+			return err
+		}
+		if int(used) >= promo.UsageLimitPerUser {
+			return errutil.NewWithCode(errutil.ErrGeneralBadRequest, entity.ErrCodePromoUserLimit, fmt.Errorf("[claimPromo] err: promo %d user %d limit reached", promoID, userID))
+		}
+	}
+	return tx.Table("promo").
+		Where("id = ?", promoID).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1")).Error
+}
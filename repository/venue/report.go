@@ -0,0 +1,140 @@
+package venue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+)
+
+// bookingReportGroups maps the report grouping to its key and label columns.
+// Bookings are dated by the booked day, not by when they were ordered.
+var bookingReportGroups = map[string][2]string{
+	entity.ReportGroupByCity:  {"CAST(ct.id AS CHAR)", "ct.name"},
+	entity.ReportGroupByVenue: {"CAST(v.id AS CHAR)", "v.name"},
+	entity.ReportGroupByDate:  {"DATE_FORMAT(o.date, '%Y-%m-%d')", "DATE_FORMAT(o.date, '%Y-%m-%d')"},
+	entity.ReportGroupByMonth: {"DATE_FORMAT(o.date, '%Y-%m')", "DATE_FORMAT(o.date, '%Y-%m')"},
+}
+
+var registrationReportGroups = map[string]string{
+	entity.ReportGroupByDate:  "DATE_FORMAT(created_at, '%Y-%m-%d')",
+	entity.ReportGroupByMonth: "DATE_FORMAT(created_at, '%Y-%m')",
+}
+
+func (r *repository) bookingReport(ctx context.Context, param *entity.ReportQuery) (*gorm.DB, error) {
+	group, ok := bookingReportGroups[param.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported group %q", param.GroupBy)
+	}
+	return r.venueOrders(ctx, nil).
+		Joins("JOIN venue v ON v.id = c.venue_id").
+		Joins("JOIN city ct ON ct.id = v.city_id").
+		Select(fmt.Sprintf("%s AS group_key, %s AS group_label, COUNT(*) AS bookings, ", group[0], group[1])+
+			"SUM(o.status <> ?) AS confirmed, SUM(o.status = ?) AS cancelled, "+
+			"COALESCE(SUM(CASE WHEN o.status <> ? THEN o.package_price + o.addon_price ELSE 0 END), 0) AS gross, "+
+			"COALESCE(SUM(CASE WHEN o.status <> ? THEN o.discount ELSE 0 END), 0) AS discount, "+
+			"COALESCE(SUM(CASE WHEN o.status <> ? THEN o.tax ELSE 0 END), 0) AS tax, "+
+			"COALESCE(SUM(CASE WHEN o.status <> ? THEN o.total_price ELSE 0 END), 0) AS revenue",
+			entity.OrderStatusCancelled, entity.OrderStatusCancelled, entity.OrderStatusCancelled,
+			entity.OrderStatusCancelled, entity.OrderStatusCancelled, entity.OrderStatusCancelled).
+		Where("o.date >= ?", param.StartDate).
+		Where("o.date < ?", param.EndDate).
+		Group("group_key, group_label"), nil
+}
+
+func (r *repository) registrationReport(ctx context.Context, param *entity.ReportQuery) (*gorm.DB, error) {
+	group, ok := registrationReportGroups[param.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported group %q", param.GroupBy)
+	}
+	return r.db.WithContext(ctx).Table("auth").
+		Select(fmt.Sprintf("%s AS group_key, COUNT(*) AS registrations", group)).
+		Where("created_at >= ?", param.StartDate).
+		Where("created_at < ?", param.EndDate).
+		Group("group_key"), nil
+}
+
+func (r *repository) StreamBookingReport(ctx context.Context, param *entity.ReportQuery, fn func(row *entity.BookingReportRow) error) error {
+	qb, err := r.bookingReport(ctx, param)
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("[StreamBookingReport] err: %v", err))
+	}
+	err = streamRows(r.paginateReport(qb.Order("group_key asc"), param), func(tx *gorm.DB, rows *sql.Rows) error {
+		var row entity.BookingReportRow
+		if err := tx.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return fn(&row)
+	})
+	if err != nil {
+		var intErr *errutil.InternalError
+		if errors.As(err, &intErr) {
+			return err
+		}
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[StreamBookingReport] err: %v", err))
+	}
+	return nil
+}
+
+func (r *repository) CountBookingReportGroups(ctx context.Context, param *entity.ReportQuery) (int, error) {
+	qb, err := r.bookingReport(ctx, param)
+	if err != nil {
+		return 0, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("[CountBookingReportGroups] err: %v", err))
+	}
+	var total int64
+	err = r.db.WithContext(ctx).Table("(?) AS report", qb).Count(&total).Error
+	if err != nil {
+		return 0, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CountBookingReportGroups] err: %v", err))
+	}
+	return int(total), nil
+}
+
+func (r *repository) StreamRegistrationReport(ctx context.Context, param *entity.ReportQuery, fn func(row *entity.RegistrationReportRow) error) error {
+	qb, err := r.registrationReport(ctx, param)
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("[StreamRegistrationReport] err: %v", err))
+	}
+	err = streamRows(r.paginateReport(qb.Order("group_key asc"), param), func(tx *gorm.DB, rows *sql.Rows) error {
+		var row entity.RegistrationReportRow
+		if err := tx.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		return fn(&row)
+	})
+	if err != nil {
+		var intErr *errutil.InternalError
+		if errors.As(err, &intErr) {
+			return err
+		}
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[StreamRegistrationReport] err: %v", err))
+	}
+	return nil
+}
+
+func (r *repository) CountRegistrationReportGroups(ctx context.Context, param *entity.ReportQuery) (int, error) {
+	qb, err := r.registrationReport(ctx, param)
+	if err != nil {
+		return 0, errutil.New(errutil.ErrGeneralBadRequest, fmt.Errorf("[CountRegistrationReportGroups] err: %v", err))
+	}
+	var total int64
+	err = r.db.WithContext(ctx).Table("(?) AS report", qb).Count(&total).Error
+	if err != nil {
+		return 0, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CountRegistrationReportGroups] err: %v", err))
+	}
+	return int(total), nil
+}
+
+func (r *repository) paginateReport(qb *gorm.DB, param *entity.ReportQuery) *gorm.DB {
+	if param.Limit <= 0 {
+		return qb
+	}
+	page := param.Page
+	if page <= 0 {
+		page = 1
+	}
+	return qb.Limit(param.Limit).Offset((page - 1) * param.Limit)
+}
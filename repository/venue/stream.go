@@ -0,0 +1,24 @@
+package venue
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// streamRows runs the query and hands every row to fn as soon as it is read
+// instead of loading the whole result set.
+func streamRows(qb *gorm.DB, fn func(tx *gorm.DB, rows *sql.Rows) error) error {
+	rows, err := qb.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(qb, rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
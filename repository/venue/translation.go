@@ -0,0 +1,81 @@
+package venue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetTranslationsByQuery(ctx context.Context, param *entity.GetTranslationQuery) ([]*entity.Translation, error) {
+	var dto []*ContentTranslation
+	qb := r.db.WithContext(ctx).Table("content_translation").
+		Where("content_type = ?", param.ContentType)
+	if len(param.ContentIDs) > 0 {
+		qb = qb.Where("content_id IN (?)", param.ContentIDs)
+	}
+	if len(param.Locales) > 0 {
+		qb = qb.Where("locale IN (?)", param.Locales)
+	}
+	err := qb.Order("content_id asc, locale asc, field asc").Find(&dto).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetTranslationsByQuery] err: %v", err))
+	}
+	out := make([]*entity.Translation, 0, len(dto))
+	for _, t := range dto {
+		out = append(out, t.ToEntity())
+	}
+	return out, nil
+}
+
+// contentTables are the tables of the translatable contents.
+var contentTables = map[string]string{
+	entity.ContentVenue:    "venue",
+	entity.ContentCategory: "venue_category_package",
+	entity.ContentPackage:  "category_package",
+}
+
+// SaveTranslations inserts or replaces the given translations, the ones
+// having an empty value are removed. The updated_at of the translated
+// contents is bumped too, it dates the responses carrying them.
+func (r *repository) SaveTranslations(ctx context.Context, translations []*entity.Translation) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, t := range translations {
+			err := tx.Table(contentTables[t.ContentType]).
+				Where("id = ?", t.ContentID).
+				UpdateColumn("updated_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+			if err != nil {
+				return err
+			}
+			qb := tx.Table("content_translation")
+			if t.Value == "" {
+				err = qb.Where("content_type = ? AND content_id = ? AND locale = ? AND field = ?", t.ContentType, t.ContentID, t.Locale, t.Field).
+					Delete(&ContentTranslation{}).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			err = qb.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]interface{}{"value": t.Value, "updated_at": gorm.Expr("CURRENT_TIMESTAMP")}),
+			}).Create(&ContentTranslation{
+				ContentType: t.ContentType,
+				ContentID:   t.ContentID,
+				Locale:      t.Locale,
+				Field:       t.Field,
+				Value:       t.Value,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[SaveTranslations] err: %v", err))
+	}
+	return nil
+}
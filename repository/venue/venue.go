@@ -10,29 +10,31 @@ import (
 
 	"github.com/faruqfadhil/venue-api/core/entity"
 	repoInterface "github.com/faruqfadhil/venue-api/core/repository"
+	"github.com/faruqfadhil/venue-api/pkg/config"
 	errutil "github.com/faruqfadhil/venue-api/pkg/error"
+	"github.com/go-sql-driver/mysql"
 	jwt "github.com/golang-jwt/jwt/v4"
 	"gorm.io/gorm"
 )
 
-const (
-	tokenSecretKey = "secret-sekali"
-)
+const mysqlErrDuplicateEntry = 1062
 
 type repository struct {
-	db *gorm.DB
+	db   *gorm.DB
+	auth config.Auth
 }
 
-func New(db *gorm.DB) repoInterface.Repository {
+func New(db *gorm.DB, cfg *config.Config) repoInterface.Repository {
 	return &repository{
-		db: db,
+		db:   db,
+		auth: cfg.Auth,
 	}
 }
 
 func (r *repository) FindUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var out entity.User
-	err := r.db.Table("auth").
-		Where("LOWER(email) = ?", strings.ToLower(email)).
+	err := r.db.WithContext(ctx).Table("auth").
+		Where("email = ?", strings.TrimSpace(email)).
 		First(&out).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -43,9 +45,40 @@ func (r *repository) FindUserByEmail(ctx context.Context, email string) (*entity
 	return &out, nil
 }
 
+func (r *repository) FindUserByID(ctx context.Context, ID int) (*entity.User, error) {
+	var out entity.User
+	err := r.db.WithContext(ctx).Table("auth").
+		Where("id = ?", ID).
+		First(&out).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("[FindUserByID] err: %v", err))
+		}
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[FindUserByID] err: %v", err))
+	}
+	return &out, nil
+}
+
+func (r *repository) FindUsersByIDs(ctx context.Context, IDs []int) ([]*entity.User, error) {
+	var out []*entity.User
+	err := r.db.WithContext(ctx).Table("auth").
+		Where("id IN (?)", IDs).
+		Find(&out).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[FindUsersByIDs] err: %v", err))
+	}
+	return out, nil
+}
+
 func (r *repository) Register(ctx context.Context, payload *entity.User) error {
-	err := r.db.Table("auth").Create(&payload).Error
+	err := r.db.WithContext(ctx).Table("auth").Create(&payload).Error
 	if err != nil {
+		// The email collation is case-insensitive, a concurrent registration
+		// of the same address in any case hits the unique key.
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return errutil.WithDetails(errutil.NewWithCode(errutil.ErrConflict, entity.ErrCodeEmailTaken, fmt.Errorf("[Register] err: %v", err)), errutil.Detail{Field: "email", Code: entity.ErrCodeEmailTaken})
+		}
 		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[Register] err: %v", err))
 	}
 	return nil
@@ -55,13 +88,15 @@ type jwtClaim struct {
 	ID       int    `json:"id"`
 	Email    string `json:"email"`
 	FullName string `json:"full_name"`
+	Role     string `json:"role"`
+	Locale   string `json:"locale,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func (r *repository) Login(ctx context.Context, email, password string) (*entity.Auth, error) {
 	var out entity.User
-	err := r.db.Table("auth").
-		Where("LOWER(email) = ?", strings.ToLower(email)).
+	err := r.db.WithContext(ctx).Table("auth").
+		Where("email = ?", strings.TrimSpace(email)).
 		Where("password = ?", password).
 		First(&out).Error
 	if err != nil {
@@ -71,33 +106,52 @@ func (r *repository) Login(ctx context.Context, email, password string) (*entity
 		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[Login] err: %v", err))
 	}
 
-	// Generate access token
-	expirationTime := time.Now().Add(24 * time.Hour)
+	return r.IssueToken(ctx, &out)
+}
+
+// IssueToken signs an access token carrying the identity and the preferences
+// of user.
+func (r *repository) IssueToken(ctx context.Context, user *entity.User) (*entity.Auth, error) {
+	expirationTime := time.Now().Add(time.Duration(r.auth.TokenTTL))
 	claim := &jwtClaim{
-		out.ID,
-		out.Email,
-		out.FullName,
+		user.ID,
+		user.Email,
+		user.FullName,
+		user.Role,
+		user.Locale,
 		jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 		}}
 
 	newToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
-	newTokenString, err := newToken.SignedString([]byte(tokenSecretKey))
+	newTokenString, err := newToken.SignedString([]byte(r.auth.JWTSecret))
 	if err != nil {
-		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[Login] err: %v", err))
+		return nil, errutil.New(errutil.ErrInternal, fmt.Errorf("[IssueToken] err: %v", err))
 	}
 
 	return &entity.Auth{
-		Email:       out.Email,
-		FullName:    out.FullName,
+		Email:       user.Email,
+		FullName:    user.FullName,
+		Role:        user.Role,
 		AccessToken: newTokenString,
+		Locale:      user.Locale,
 	}, nil
 }
 
+func (r *repository) UpdateUserLocale(ctx context.Context, userID int, locale string) error {
+	err := r.db.WithContext(ctx).Table("auth").
+		Where("id = ?", userID).
+		UpdateColumn("locale", locale).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[UpdateUserLocale] err: %v", err))
+	}
+	return nil
+}
+
 func (r *repository) ValidateToken(ctx context.Context, token string) (*entity.CredentialClaim, error) {
 	claim := &jwtClaim{}
 	jwtToken, err := jwt.ParseWithClaims(token, claim, func(t *jwt.Token) (interface{}, error) {
-		return []byte(tokenSecretKey), nil
+		return []byte(r.auth.JWTSecret), nil
 	})
 	if err != nil {
 		return nil, errutil.New(errutil.ErrUnauthorized, fmt.Errorf("[ValidateToken] err: %v", err))
@@ -109,6 +163,8 @@ func (r *repository) ValidateToken(ctx context.Context, token string) (*entity.C
 		ID:       claim.ID,
 		Email:    claim.Email,
 		FullName: claim.FullName,
+		Role:     claim.Role,
+		Locale:   claim.Locale,
 	}, nil
 }
 
@@ -117,13 +173,13 @@ func (r *repository) GetVenues(ctx context.Context, param entity.GetVenuesParam)
 	if param.Page <= 0 {
 		param.Page = 1
 	}
-	if param.Limit <= 0 {
-		param.Limit = 10
-	}
-	qb := r.db.Table("venue")
+	qb := r.db.WithContext(ctx).Table("venue")
 	if param.ID > 0 {
 		qb = qb.Where("id = ?", param.ID)
 	}
+	if len(param.IDs) > 0 {
+		qb = qb.Where("id IN (?)", param.IDs)
+	}
 	if len(param.NotInIDs) > 0 {
 		qb = qb.Where("id NOT IN (?)", param.NotInIDs)
 	}
@@ -137,6 +193,11 @@ func (r *repository) GetVenues(ctx context.Context, param entity.GetVenuesParam)
 		qb = qb.Where("is_favourite = ?", param.IsFavourite)
 	}
 
+	sortColumn, desc := venueSort(param.Sort)
+	if len(param.Fields) > 0 {
+		qb = qb.Select(venueSelect(param.Fields, sortColumn))
+	}
+
 	var pag *entity.Pagination
 	if param.IsWithoutPagination {
 		err := qb.Find(&result).Error
@@ -144,27 +205,47 @@ func (r *repository) GetVenues(ctx context.Context, param entity.GetVenuesParam)
 			return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetVenues] err: %v", err))
 		}
 	} else {
-		var totalRecords int64
-		err := qb.Count(&totalRecords).Error
-		if err != nil {
-			return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetVenues] err: %v", err))
+		pag = &entity.Pagination{}
+		if !param.SkipCount {
+			var totalRecords int64
+			err := qb.Session(&gorm.Session{}).Count(&totalRecords).Error
+			if err != nil {
+				return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetVenues] err: %v", err))
+			}
+			pag.TotalItems = int(totalRecords)
+			pag.TotalPage = int(math.Ceil(float64(totalRecords) / float64(param.Limit)))
 		}
 
-		offset := (param.Page - 1) * param.Limit
-		qb = qb.Order("id asc")
-		data := qb
-		err = data.Limit(param.Limit).Offset(offset).Find(&result).Error
+		// A cursor continues after the last venue of the previous page, so
+		// venues added meanwhile don't shift the pages.
+		if param.Cursor != "" {
+			cursor, err := decodeVenueCursor(param.Cursor, param.Sort)
+			if err != nil {
+				return nil, nil, err
+			}
+			qb = afterVenueCursor(qb, cursor, sortColumn, desc)
+		} else {
+			qb = qb.Offset((param.Page - 1) * param.Limit)
+			pag.Page = param.Page
+		}
+		dir := "asc"
+		if desc {
+			dir = "desc"
+		}
+		if sortColumn != "id" {
+			qb = qb.Order(sortColumn + " " + dir)
+		}
+		// One more venue than asked tells whether there is a next page.
+		err := qb.Order("id " + dir).Limit(param.Limit + 1).Find(&result).Error
 		if err != nil {
 			return nil, nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetVenues] err: %v", err))
 		}
-
-		totalPage := math.Ceil(float64(totalRecords) / float64(param.Limit))
-		pag = &entity.Pagination{
-			Page:         param.Page,
-			TotalPage:    int(totalPage),
-			CurrentItems: len(result),
-			TotalItems:   int(totalRecords),
+		if len(result) > param.Limit {
+			result = result[:param.Limit]
+			last := result[len(result)-1]
+			pag.NextCursor = encodeVenueCursor(venueCursor{Sort: param.Sort, Key: last.sortKey(sortColumn), ID: last.ID})
 		}
+		pag.CurrentItems = len(result)
 	}
 
 	out := []*entity.Venue{}
@@ -177,7 +258,7 @@ func (r *repository) GetVenues(ctx context.Context, param entity.GetVenuesParam)
 
 func (r *repository) GetCities(ctx context.Context) ([]*entity.City, error) {
 	var cities []*entity.City
-	err := r.db.Table("city").Find(&cities).Error
+	err := r.db.WithContext(ctx).Table("city").Find(&cities).Error
 	if err != nil {
 		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetCities] err: %v", err))
 	}
@@ -186,9 +267,10 @@ func (r *repository) GetCities(ctx context.Context) ([]*entity.City, error) {
 
 func (r *repository) GetOrderByPackageIDAndDate(ctx context.Context, packageID int, date time.Time) (*entity.Order, error) {
 	var out entity.Order
-	err := r.db.Table("order").
+	err := r.db.WithContext(ctx).Table("order").
 		Where("package_id = ?", packageID).
 		Where("date = ?", date).
+		Where("status <> ?", entity.OrderStatusCancelled).
 		First(&out).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -199,9 +281,77 @@ func (r *repository) GetOrderByPackageIDAndDate(ctx context.Context, packageID i
 	return &out, nil
 }
 
+func (r *repository) GetOrderByID(ctx context.Context, ID int) (*entity.Order, error) {
+	var out entity.Order
+	err := r.db.WithContext(ctx).Table("order").
+		Where("id = ?", ID).
+		First(&out).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errutil.New(errutil.ErrGeneralNotFound, fmt.Errorf("[GetOrderByID] err: %v", err))
+		}
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetOrderByID] err: %v", err))
+	}
+
+	err = r.db.WithContext(ctx).Table("order_addon").
+		Where("order_id = ?", ID).
+		Order("id asc").
+		Find(&out.Addons).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetOrderByID] err: %v", err))
+	}
+	return &out, nil
+}
+
 func (r *repository) CreateOrder(ctx context.Context, order *entity.Order) error {
-	err := r.db.Table("order").Create(&order).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if order.PromoID > 0 {
+			// Lock the promo row so concurrent orders using the same code are
+			// serialized and the usage limits can't be exceeded.
+			if err := claimPromo(tx, order.PromoID, order.UserID); err != nil {
+				return err
+			}
+		}
+		if order.Status == "" {
+			order.Status = entity.OrderStatusConfirmed
+		}
+		if order.PaymentStatus == "" {
+			order.PaymentStatus = entity.PaymentStatusUnpaid
+		}
+		if err := tx.Table("order").Create(&order).Error; err != nil {
+			return err
+		}
+		order.InvoiceNumber = entity.InvoiceNumber(order.ID, order.CreatedAt)
+		err := tx.Table("order").
+			Where("id = ?", order.ID).
+			UpdateColumn("invoice_number", order.InvoiceNumber).Error
+		if err != nil {
+			return err
+		}
+		if order.PromoID > 0 {
+			err := tx.Table("promo_usage").Create(&PromoUsage{
+				PromoID:  order.PromoID,
+				UserID:   order.UserID,
+				OrderID:  order.ID,
+				Discount: order.Discount,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(order.Addons) < 1 {
+			return nil
+		}
+		for _, ad := range order.Addons {
+			ad.OrderID = order.ID
+		}
+		return tx.Table("order_addon").Create(&order.Addons).Error
+	})
 	if err != nil {
+		var intErr *errutil.InternalError
+		if errors.As(err, &intErr) {
+			return err
+		}
 		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[CreateOrder] err: %v", err))
 	}
 	return nil
@@ -209,7 +359,7 @@ func (r *repository) CreateOrder(ctx context.Context, order *entity.Order) error
 
 func (r *repository) GetPackageByID(ctx context.Context, ID int) (*entity.VenuePackage, error) {
 	var out entity.VenuePackage
-	err := r.db.Table("category_package").
+	err := r.db.WithContext(ctx).Table("category_package").
 		Where("id = ?", ID).
 		First(&out).Error
 	if err != nil {
@@ -223,7 +373,7 @@ func (r *repository) GetPackageByID(ctx context.Context, ID int) (*entity.VenueP
 
 func (r *repository) GetGalleriesByVenueIDs(ctx context.Context, IDs []int) (map[int][]string, error) {
 	var out []*VenueGallery
-	err := r.db.Table("venue_gallery").
+	err := r.db.WithContext(ctx).Table("venue_gallery").
 		Where("venue_id IN (?)", IDs).Find(&out).Error
 	if err != nil {
 		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetGalleriesByVenueIDs] err: %v", err))
@@ -238,8 +388,9 @@ func (r *repository) GetGalleriesByVenueIDs(ctx context.Context, IDs []int) (map
 
 func (r *repository) GetOrdersByDate(ctx context.Context, date time.Time) ([]*entity.Order, error) {
 	var out []*entity.Order
-	err := r.db.Table("order").
+	err := r.db.WithContext(ctx).Table("order").
 		Where("date = ?", date).
+		Where("status <> ?", entity.OrderStatusCancelled).
 		Find(&out).Error
 	if err != nil {
 		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetOrdersByDate] err: %v", err))
@@ -247,9 +398,57 @@ func (r *repository) GetOrdersByDate(ctx context.Context, date time.Time) ([]*en
 	return out, nil
 }
 
+func (r *repository) GetOrdersByQuery(ctx context.Context, param *entity.GetOrderQuery) ([]*entity.Order, error) {
+	var out []*entity.Order
+	qb := r.db.WithContext(ctx).Table("order")
+	if len(param.PackageIDs) > 0 {
+		qb = qb.Where("package_id IN (?)", param.PackageIDs)
+	}
+	if len(param.Statuses) > 0 {
+		qb = qb.Where("status IN (?)", param.Statuses)
+	}
+	if !param.StartDate.IsZero() {
+		qb = qb.Where("date >= ?", param.StartDate)
+	}
+	if !param.EndDate.IsZero() {
+		qb = qb.Where("date <= ?", param.EndDate)
+	}
+
+	err := qb.Order("date asc, id asc").Find(&out).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetOrdersByQuery] err: %v", err))
+	}
+	return out, nil
+}
+
+func (r *repository) UpdateVenueCalendarToken(ctx context.Context, venueID int, token string) error {
+	err := r.db.WithContext(ctx).Table("venue").
+		Where("id = ?", venueID).
+		UpdateColumn("calendar_token", token).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[UpdateVenueCalendarToken] err: %v", err))
+	}
+	return nil
+}
+
+// MarkOrderPaid records the payment of an unpaid order, an order already paid
+// keeps its first payment time.
+func (r *repository) MarkOrderPaid(ctx context.Context, ID int, paidAt time.Time) error {
+	err := r.db.WithContext(ctx).Table("order").
+		Where("id = ? AND payment_status = ?", ID, entity.PaymentStatusUnpaid).
+		Updates(map[string]interface{}{
+			"payment_status": entity.PaymentStatusPaid,
+			"paid_at":        paidAt,
+		}).Error
+	if err != nil {
+		return errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[MarkOrderPaid] err: %v", err))
+	}
+	return nil
+}
+
 func (r *repository) GetVenuePackageByQuery(ctx context.Context, param *entity.GetVenuePackageQuery) ([]*entity.VenuePackage, error) {
 	var dto []*VenuePackage
-	qb := r.db.Table("category_package")
+	qb := r.db.WithContext(ctx).Table("category_package")
 	if len(param.IDs) > 0 {
 		qb = qb.Where("id IN (?)", param.IDs)
 	}
@@ -272,13 +471,16 @@ func (r *repository) GetVenuePackageByQuery(ctx context.Context, param *entity.G
 
 func (r *repository) GetVenueCategoryPackageByQuery(ctx context.Context, param *entity.GetVenueCategoryByQuery) ([]*entity.VenuePackageCategory, error) {
 	var dto []*VenuePackageCategory
-	qb := r.db.Table("venue_category_package")
+	qb := r.db.WithContext(ctx).Table("venue_category_package")
 	if len(param.IDs) > 0 {
 		qb = qb.Where("id IN (?)", param.IDs)
 	}
 	if param.VenueID > 0 {
 		qb = qb.Where("venue_id = ?", param.VenueID)
 	}
+	if len(param.VenueIDs) > 0 {
+		qb = qb.Where("venue_id IN (?)", param.VenueIDs)
+	}
 
 	err := qb.Find(&dto).Error
 	if err != nil {
@@ -291,3 +493,42 @@ func (r *repository) GetVenueCategoryPackageByQuery(ctx context.Context, param *
 	}
 	return out, nil
 }
+
+func (r *repository) GetPackageAddonsByQuery(ctx context.Context, param *entity.GetPackageAddonQuery) ([]*entity.PackageAddon, error) {
+	var dto []*PackageAddon
+	qb := r.db.WithContext(ctx).Table("package_addon")
+	if len(param.IDs) > 0 {
+		qb = qb.Where("id IN (?)", param.IDs)
+	}
+	if len(param.PackageIDs) > 0 {
+		qb = qb.Where("package_id IN (?)", param.PackageIDs)
+	}
+
+	err := qb.Order("id asc").Find(&dto).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPackageAddonsByQuery] err: %v", err))
+	}
+
+	out := []*entity.PackageAddon{}
+	for _, dt := range dto {
+		out = append(out, dt.ToEntity())
+	}
+	return out, nil
+}
+
+func (r *repository) GetPriceRulesByPackageIDs(ctx context.Context, IDs []int) (map[int][]*entity.PriceRule, error) {
+	var dto []*PackagePriceRule
+	err := r.db.WithContext(ctx).Table("package_price_rule").
+		Where("package_id IN (?)", IDs).
+		Order("priority asc, id asc").
+		Find(&dto).Error
+	if err != nil {
+		return nil, errutil.New(errutil.ErrGeneralDB, fmt.Errorf("[GetPriceRulesByPackageIDs] err: %v", err))
+	}
+
+	rulesMappedByPackageID := map[int][]*entity.PriceRule{}
+	for _, dt := range dto {
+		rulesMappedByPackageID[dt.PackageID] = append(rulesMappedByPackageID[dt.PackageID], dt.ToEntity())
+	}
+	return rulesMappedByPackageID, nil
+}
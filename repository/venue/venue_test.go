@@ -0,0 +1,66 @@
+package venue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/pkg/civil"
+)
+
+// TestOrderDateRoundTrip checks a booked day is stored and read back as the
+// same civil date, whatever the venue timezone.
+func TestOrderDateRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	repo := newTestRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		timezone string
+		date     string
+	}{
+		{"wib", "Asia/Jakarta", "2030-03-10"},
+		{"wita", "Asia/Makassar", "2030-03-11"},
+		{"leap day", "Asia/Jakarta", "2032-02-29"},
+		{"new year", "Asia/Jayapura", "2031-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := seedFixture(t, db, tt.timezone)
+			date, err := civil.Parse(tt.date)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			order := &entity.Order{PackageID: f.PackageID, UserID: f.UserID, Date: date, PackagePrice: 1000, TotalPrice: 1110}
+			if err := repo.CreateOrder(ctx, order); err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+
+			var stored string
+			if err := db.Raw("SELECT DATE_FORMAT(`date`, '%Y-%m-%d') FROM `order` WHERE id = ?", order.ID).Scan(&stored).Error; err != nil {
+				t.Fatalf("read column: %v", err)
+			}
+			if stored != tt.date {
+				t.Errorf("stored %s, want %s", stored, tt.date)
+			}
+
+			got, err := repo.GetOrderByID(ctx, order.ID)
+			if err != nil {
+				t.Fatalf("GetOrderByID: %v", err)
+			}
+			if !got.Date.Equal(date) || got.Date.Location() != time.UTC || got.Date.Format(civil.Layout) != tt.date {
+				t.Errorf("read back %v, want %v", got.Date, date)
+			}
+
+			found, err := repo.GetOrderByPackageIDAndDate(ctx, f.PackageID, date)
+			if err != nil {
+				t.Fatalf("GetOrderByPackageIDAndDate: %v", err)
+			}
+			if found.ID != order.ID {
+				t.Errorf("found order %d, want %d", found.ID, order.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"time"
+
+	"github.com/faruqfadhil/venue-api/core/entity"
+	"github.com/faruqfadhil/venue-api/handler"
+	"github.com/faruqfadhil/venue-api/pkg/api"
+	"github.com/faruqfadhil/venue-api/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// registerRoutes serves the API on router. Every route must be documented in
+// handler/openapi.go, see TestRoutesDocumented.
+func registerRoutes(router *gin.Engine, hdlr *handler.HTTPHandler, mw *api.MiddlewareService, cfg config.HTTP) {
+	router.GET("/healthz", hdlr.Liveness)
+	router.GET("/readyz", hdlr.Readiness)
+	router.GET("/openapi.json", hdlr.OpenAPI)
+	router.GET("/docs", hdlr.SwaggerUI)
+
+	requestTimeout := mw.Timeout(time.Duration(cfg.RequestTimeout))
+	exportTimeout := mw.Timeout(time.Duration(cfg.ExportTimeout))
+
+	v1 := router.Group("/v1")
+	v1.Use(requestTimeout)
+	{
+		v1.GET("/city", hdlr.GetCities)
+		v1.POST("/register", hdlr.Register)
+		v1.POST("/login", hdlr.Login)
+		v1.GET("/venue", hdlr.GetVenues)
+		v1.GET("/nearby", hdlr.GetNearby)
+		v1.GET("/venue/:id", hdlr.GetVenueDetail)
+		v1.GET("/venue/:id/calendar.ics", hdlr.GetVenueCalendar)
+		v1.GET("/venue/package/:id", hdlr.GetPackageDetail)
+		v1.GET("/venue/package/:id/price", hdlr.GetPackagePrice)
+	}
+	usingAuth := router.Group("/v1")
+	usingAuth.Use(requestTimeout, mw.AuthenticateRequest())
+	{
+		usingAuth.POST("/venue/package/order", hdlr.CreateOrder)
+		usingAuth.POST("/promo/validate", hdlr.ValidatePromo)
+		usingAuth.GET("/orders/:id/invoice", hdlr.GetOrderInvoice)
+		usingAuth.GET("/orders/:id/calendar.ics", hdlr.GetOrderCalendar)
+		usingAuth.PUT("/account/locale", hdlr.UpdateLocale)
+	}
+	owner := router.Group("/v1/owner")
+	owner.Use(requestTimeout, mw.AuthenticateRequest(), mw.AuthorizeRole(entity.RoleOwner, entity.RoleAdmin))
+	{
+		owner.GET("/venues", hdlr.GetOwnerVenues)
+		owner.GET("/orders", hdlr.GetOwnerOrders)
+		owner.GET("/venue/:id/calendar", hdlr.GetVenueOccupancy)
+		owner.GET("/stats", hdlr.GetVenueStats)
+		owner.GET("/package/:id/price-rules", hdlr.GetPriceRules)
+		owner.POST("/package/:id/price-rules", hdlr.CreatePriceRule)
+		owner.PUT("/price-rules/:id", hdlr.UpdatePriceRule)
+		owner.DELETE("/price-rules/:id", hdlr.DeletePriceRule)
+	}
+	admin := router.Group("/v1/admin")
+	admin.Use(mw.AuthenticateRequest(), mw.AuthorizeRole(entity.RoleAdmin))
+	{
+		// a deadline can only be shortened by a nested context, so exports
+		// and imports get their own timeout instead of a group-wide one.
+		admin.GET("/promo", requestTimeout, hdlr.GetPromos)
+		admin.POST("/promo", requestTimeout, hdlr.CreatePromo)
+		admin.PUT("/promo/:id", requestTimeout, hdlr.UpdatePromo)
+		admin.POST("/venue/:id/calendar-token", requestTimeout, hdlr.RotateVenueCalendarToken)
+		admin.PUT("/venue/:id/owners/:userId", requestTimeout, hdlr.AssignVenueOwner)
+		admin.DELETE("/venue/:id/owners/:userId", requestTimeout, hdlr.RemoveVenueOwner)
+		admin.POST("/orders/:id/paid", requestTimeout, hdlr.MarkOrderPaid)
+		admin.GET("/content/:type/:id/translations", requestTimeout, hdlr.GetTranslations)
+		admin.PUT("/content/:type/:id/translations/:locale", requestTimeout, hdlr.UpdateTranslations)
+		admin.GET("/reports/:name", exportTimeout, hdlr.GetReport)
+		admin.POST("/venues/import", mw.ReadTimeout(time.Duration(cfg.ExportTimeout)), exportTimeout, hdlr.ImportVenues)
+	}
+}